@@ -0,0 +1,11 @@
+data "eck_cluster_nodes" "example" {
+  name  = eck_cluster.demo.name
+  eckcp = eck_cluster.demo.eckcp
+}
+
+# Internal IP addresses of the nodes in each workload pool.
+output "pool_addresses" {
+  value = {
+    for node in data.eck_cluster_nodes.example.nodes : node.pool => node.internal_ip... if !node.control_plane
+  }
+}
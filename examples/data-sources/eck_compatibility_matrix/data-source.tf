@@ -0,0 +1,15 @@
+data "eck_compatibility_matrix" "example" {}
+
+locals {
+  kubernetes_versions = data.eck_compatibility_matrix.example.kubernetes_versions
+
+  # The newest Kubernetes version, and the newest image providing it.
+  latest_version = local.kubernetes_versions[length(local.kubernetes_versions) - 1].version
+  latest_image   = local.kubernetes_versions[length(local.kubernetes_versions) - 1].images[0]
+
+  # Bundles which are neither in preview nor end of life.
+  supported_bundles = [
+    for b in data.eck_compatibility_matrix.example.application_bundles : b.name
+    if !b.preview && try(timecmp(b.end_of_life, plantimestamp()) > 0, true)
+  ]
+}
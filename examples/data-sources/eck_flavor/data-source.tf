@@ -0,0 +1,16 @@
+data "eck_flavor" "gpu" {
+  min_cpus   = 8
+  min_memory = 32
+  gpus       = 1
+}
+
+resource "eck_cluster" "demo" {
+  # ...
+  workloadnodepools = [
+    {
+      name   = "gpu"
+      flavor = data.eck_flavor.gpu.name
+      # ...
+    },
+  ]
+}
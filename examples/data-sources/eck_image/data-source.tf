@@ -0,0 +1,12 @@
+data "eck_image" "kubernetes" {
+  version = var.k8s_version
+}
+
+resource "eck_cluster" "demo" {
+  # ...
+  controlplane = {
+    version = var.k8s_version
+    image   = data.eck_image.kubernetes.name
+    # ...
+  }
+}
@@ -0,0 +1,6 @@
+# Wait for a cluster created by another configuration to be provisioned.
+data "eck_kubeconfig" "example" {
+  name  = "my-cluster"
+  eckcp = "default"
+  wait  = true
+}
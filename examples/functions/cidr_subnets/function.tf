@@ -0,0 +1,31 @@
+# Give each cluster a /14 of 10.0.0.0/8, split into a /15 for pods and /16s
+# for services and nodes.
+resource "eck_cluster" "team" {
+  count = 4
+
+  name              = "team-${count.index}"
+  eckcp             = "default"
+  applicationbundle = "kubernetes-cluster-1.4.0"
+  clusternetwork = merge(
+    provider::eck::cidr_subnets(cidrsubnet("10.0.0.0/8", 6, count.index)),
+    { dnsnameservers = ["1.1.1.1", "1.0.0.1"] },
+  )
+  clusteropenstack = {
+    externalnetworkid = "70bb46a1-4d43-485d-9dbc-4aa979990327"
+  }
+  controlplane = {
+    flavor   = "m1.large"
+    image    = "eck-231023-a16c4645"
+    replicas = 1
+    version  = "v1.28.3"
+  }
+  workloadnodepools = [
+    {
+      name     = "cpu"
+      replicas = 1
+      image    = "eck-231023-a16c4645"
+      version  = "v1.28.3"
+      flavor   = "m1.large"
+    }
+  ]
+}
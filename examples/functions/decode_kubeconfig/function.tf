@@ -0,0 +1,10 @@
+locals {
+  kubeconfig = provider::eck::decode_kubeconfig(eck_cluster.demo.kubeconfig)
+}
+
+provider "kubernetes" {
+  host                   = local.kubeconfig.host
+  cluster_ca_certificate = local.kubeconfig.cluster_ca_certificate
+  client_certificate     = local.kubeconfig.client_certificate
+  client_key             = local.kubeconfig.client_key
+}
@@ -0,0 +1,6 @@
+locals {
+  version = "v1.28.3"
+
+  # Sidecar containers are enabled by default from Kubernetes v1.29.
+  native_sidecars = provider::eck::version_at_least(local.version, "v1.29")
+}
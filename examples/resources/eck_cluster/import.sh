@@ -0,0 +1,2 @@
+# Clusters are imported by control plane and cluster name.
+terraform import eck_cluster.demo default/demo
@@ -0,0 +1,2 @@
+# Control planes are imported by name.
+terraform import eck_controlplane.default default
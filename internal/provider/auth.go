@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/eschercloudai/eckctl/pkg/generated"
+	"golang.org/x/oauth2"
+)
+
+// getToken obtains a project scoped access token from the ECK API.  This
+// mirrors auth.GetToken from eckctl, but uses the provider's HTTP client so
+// that TLS and retry settings also apply to authentication.
+func getToken(ctx context.Context, httpClient *http.Client, userAgent string, headers map[string]string, host string, username string, password string, project string) (string, error) {
+	config := &oauth2.Config{
+		Endpoint: oauth2.Endpoint{
+			TokenURL: host + "/api/v1/auth/oauth2/tokens",
+		},
+	}
+
+	// The password grant is made by the oauth2 package, so the headers are
+	// added by the transport instead.
+	oauth2Client := &http.Client{
+		Transport: newEditorTransport(httpClient.Transport, userAgentEditor(userAgent), headersEditor(headers)),
+		Timeout:   httpClient.Timeout,
+	}
+
+	token, err := config.PasswordCredentialsToken(context.WithValue(ctx, oauth2.HTTPClient, oauth2Client), username, password)
+	if err != nil {
+		return "", err
+	}
+
+	// Exchange the unscoped token for one scoped to the project.
+	client, err := generated.NewClientWithResponses(host,
+		generated.WithHTTPClient(httpClient),
+		generated.WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+			return nil
+		}),
+		generated.WithRequestEditorFn(userAgentEditor(userAgent)),
+		generated.WithRequestEditorFn(headersEditor(headers)),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	scope := generated.TokenScope{
+		Project: generated.TokenScopeProject{
+			Id: project,
+		},
+	}
+
+	r, err := client.PostApiV1AuthTokensTokenWithResponse(ctx, scope)
+	if err != nil {
+		return "", err
+	}
+
+	if r.JSON201 == nil {
+		return "", newAPIError(r.StatusCode(), r.Status(), r.Body)
+	}
+
+	return r.JSON201.AccessToken, nil
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/eschercloudai/eckctl/pkg/generated"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// daysOfWeekModel maps the auto-upgrade days of week schema data.
+type daysOfWeekModel struct {
+	Monday    *timeWindowModel `tfsdk:"monday"`
+	Tuesday   *timeWindowModel `tfsdk:"tuesday"`
+	Wednesday *timeWindowModel `tfsdk:"wednesday"`
+	Thursday  *timeWindowModel `tfsdk:"thursday"`
+	Friday    *timeWindowModel `tfsdk:"friday"`
+	Saturday  *timeWindowModel `tfsdk:"saturday"`
+	Sunday    *timeWindowModel `tfsdk:"sunday"`
+}
+
+type timeWindowModel struct {
+	Start types.Int64 `tfsdk:"start"`
+	End   types.Int64 `tfsdk:"end"`
+}
+
+var weekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// daysOfWeekAttribute returns the resource schema for an auto-upgrade window.
+func daysOfWeekAttribute() schema.SingleNestedAttribute {
+	days := map[string]schema.Attribute{}
+	for _, day := range weekdays {
+		days[day] = schema.SingleNestedAttribute{
+			Description: fmt.Sprintf("The time window on %s in which upgrades may be performed.", day),
+			Optional:    true,
+			Attributes: map[string]schema.Attribute{
+				"start": schema.Int64Attribute{
+					Description: "The hour of the day, in UTC, at which the window opens.",
+					Required:    true,
+					Validators: []validator.Int64{
+						int64validator.Between(0, 23),
+					},
+				},
+				"end": schema.Int64Attribute{
+					Description: "The hour of the day, in UTC, at which the window closes.  Windows wrap into the next day if `end` is before `start`.",
+					Required:    true,
+					Validators: []validator.Int64{
+						int64validator.Between(0, 23),
+					},
+				},
+			},
+		}
+	}
+
+	return schema.SingleNestedAttribute{
+		Description: "Days of the week and time windows in which automatic upgrades may be performed.  Days which are omitted do not permit upgrades.",
+		Optional:    true,
+		Attributes:  days,
+	}
+}
+
+// daysOfWeekDataSourceAttribute returns the data source schema for an
+// auto-upgrade window.
+func daysOfWeekDataSourceAttribute() datasourceschema.SingleNestedAttribute {
+	days := map[string]datasourceschema.Attribute{}
+	for _, day := range weekdays {
+		days[day] = datasourceschema.SingleNestedAttribute{
+			Description: fmt.Sprintf("The time window on %s in which upgrades may be performed.", day),
+			Computed:    true,
+			Attributes: map[string]datasourceschema.Attribute{
+				"start": datasourceschema.Int64Attribute{
+					Description: "The hour of the day, in UTC, at which the window opens.",
+					Computed:    true,
+				},
+				"end": datasourceschema.Int64Attribute{
+					Description: "The hour of the day, in UTC, at which the window closes.",
+					Computed:    true,
+				},
+			},
+		}
+	}
+
+	return datasourceschema.SingleNestedAttribute{
+		Description: "Days of the week and time windows in which automatic upgrades may be performed.",
+		Computed:    true,
+		Attributes:  days,
+	}
+}
+
+func generateTimeWindow(m *timeWindowModel) *generated.TimeWindow {
+	if m == nil {
+		return nil
+	}
+
+	return &generated.TimeWindow{
+		Start: int(m.Start.ValueInt64()),
+		End:   int(m.End.ValueInt64()),
+	}
+}
+
+func generateTimeWindowModel(w *generated.TimeWindow) *timeWindowModel {
+	if w == nil {
+		return nil
+	}
+
+	return &timeWindowModel{
+		Start: types.Int64Value(int64(w.Start)),
+		End:   types.Int64Value(int64(w.End)),
+	}
+}
+
+// generateDaysOfWeek renders the auto-upgrade window for the API.
+func generateDaysOfWeek(m *daysOfWeekModel) *generated.AutoUpgradeDaysOfWeek {
+	if m == nil {
+		return nil
+	}
+
+	return &generated.AutoUpgradeDaysOfWeek{
+		Monday:    generateTimeWindow(m.Monday),
+		Tuesday:   generateTimeWindow(m.Tuesday),
+		Wednesday: generateTimeWindow(m.Wednesday),
+		Thursday:  generateTimeWindow(m.Thursday),
+		Friday:    generateTimeWindow(m.Friday),
+		Saturday:  generateTimeWindow(m.Saturday),
+		Sunday:    generateTimeWindow(m.Sunday),
+	}
+}
+
+// generateDaysOfWeekModel renders the API auto-upgrade window for Terraform
+// state.
+func generateDaysOfWeekModel(d *generated.AutoUpgradeDaysOfWeek) *daysOfWeekModel {
+	if d == nil {
+		return nil
+	}
+
+	return &daysOfWeekModel{
+		Monday:    generateTimeWindowModel(d.Monday),
+		Tuesday:   generateTimeWindowModel(d.Tuesday),
+		Wednesday: generateTimeWindowModel(d.Wednesday),
+		Thursday:  generateTimeWindowModel(d.Thursday),
+		Friday:    generateTimeWindowModel(d.Friday),
+		Saturday:  generateTimeWindowModel(d.Saturday),
+		Sunday:    generateTimeWindowModel(d.Sunday),
+	}
+}
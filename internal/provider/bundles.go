@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eschercloudai/eckctl/pkg/generated"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// compareBundleVersions compares two application bundle versions, e.g. 1.4.1,
+// numerically component by component, returning -1, 0 or 1.  Components which
+// are not numbers compare as zero.
+func compareBundleVersions(a string, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var x, y int
+
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+
+		if x != y {
+			if x < y {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// latestApplicationBundle returns the newest application bundle which is
+// neither in preview nor past its end of life.
+func latestApplicationBundle(bundles []generated.ApplicationBundle) (*generated.ApplicationBundle, error) {
+	now := time.Now()
+
+	var latest *generated.ApplicationBundle
+
+	for i := range bundles {
+		bundle := &bundles[i]
+
+		if (bundle.Preview != nil && *bundle.Preview) || (bundle.EndOfLife != nil && !bundle.EndOfLife.After(now)) {
+			continue
+		}
+
+		if latest == nil || compareBundleVersions(bundle.Version, latest.Version) > 0 {
+			latest = bundle
+		}
+	}
+
+	if latest == nil {
+		return nil, errors.New("no supported application bundles are available")
+	}
+
+	return latest, nil
+}
+
+// latestClusterBundle returns the name of the newest supported cluster
+// application bundle.
+func latestClusterBundle(ctx context.Context, catalog *clusterCatalog) (string, error) {
+	bundles, err := catalog.listClusterBundles(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	bundle, err := latestApplicationBundle(bundles)
+	if err != nil {
+		return "", err
+	}
+
+	return bundle.Name, nil
+}
+
+// latestControlPlaneBundle returns the version of the newest supported control
+// plane application bundle.
+func latestControlPlaneBundle(ctx context.Context, client *generated.ClientWithResponses) (string, error) {
+	r, err := client.GetApiV1ApplicationbundlesControlPlaneWithResponse(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if r.JSON200 == nil {
+		return "", newAPIError(r.StatusCode(), r.Status(), r.Body)
+	}
+
+	bundle, err := latestApplicationBundle(*r.JSON200)
+	if err != nil {
+		return "", err
+	}
+
+	return bundle.Version, nil
+}
+
+// checkClusterBundle warns when a cluster's application bundle is in preview,
+// or has reached, or is scheduled to reach, its end of life, after which the
+// platform upgrades clusters using it automatically.  Errors listing bundles
+// are reported by checkReferences.
+func checkClusterBundle(ctx context.Context, catalog *clusterCatalog, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	bundles, err := catalog.listClusterBundles(ctx)
+	if err != nil {
+		return diags
+	}
+
+	var bundle *generated.ApplicationBundle
+
+	for i := range bundles {
+		if bundles[i].Name == name {
+			bundle = &bundles[i]
+		}
+	}
+
+	// Missing bundles are reported by checkReferences.
+	if bundle == nil {
+		return diags
+	}
+
+	if bundle.Preview != nil && *bundle.Preview {
+		diags.AddAttributeWarning(
+			path.Root("applicationbundle"),
+			"Application Bundle Is in Preview",
+			fmt.Sprintf("Application bundle %q is a preview release, which may be unstable and is not recommended for production clusters.", name),
+		)
+	}
+
+	if bundle.EndOfLife == nil {
+		return diags
+	}
+
+	hint := ""
+	if latest, err := latestApplicationBundle(bundles); err == nil && latest.Name != name {
+		hint = fmt.Sprintf("  Set applicationbundle to a supported bundle, e.g. %q, to control when the upgrade happens.", latest.Name)
+	}
+
+	endOfLife := bundle.EndOfLife.UTC().Format(time.DateOnly)
+
+	if bundle.EndOfLife.After(time.Now()) {
+		diags.AddAttributeWarning(
+			path.Root("applicationbundle"),
+			"Application Bundle Is Deprecated",
+			fmt.Sprintf("Application bundle %q reaches end of life on %s, after which clusters using it are upgraded automatically.%s", name, endOfLife, hint),
+		)
+
+		return diags
+	}
+
+	diags.AddAttributeWarning(
+		path.Root("applicationbundle"),
+		"Application Bundle Is End of Life",
+		fmt.Sprintf("Application bundle %q reached end of life on %s, and clusters using it will be upgraded automatically.%s", name, endOfLife, hint),
+	)
+
+	return diags
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eschercloudai/eckctl/pkg/generated"
+)
+
+func TestCompareBundleVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{a: "1.4.1", b: "1.4.1", want: 0},
+		{a: "1.4.1", b: "1.4.2", want: -1},
+		{a: "1.4.2", b: "1.4.1", want: 1},
+		{a: "1.10.0", b: "1.9.0", want: 1},
+		{a: "2.0.0", b: "1.99.99", want: 1},
+		{a: "1.4", b: "1.4.0", want: 0},
+		{a: "1.4", b: "1.4.1", want: -1},
+		{a: "1.4.1.1", b: "1.4.1", want: 1},
+		{a: "v1.4.1", b: "1.4.1", want: 0},
+		{a: "v1.5.0", b: "v1.4.9", want: 1},
+		{a: "1.x.0", b: "1.0.0", want: 0},
+		{a: "", b: "0.0.1", want: -1},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.a+" "+test.b, func(t *testing.T) {
+			t.Parallel()
+
+			if got := compareBundleVersions(test.a, test.b); got != test.want {
+				t.Errorf("compareBundleVersions(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+			}
+		})
+	}
+}
+
+func TestLatestApplicationBundle(t *testing.T) {
+	t.Parallel()
+
+	yes := true
+	no := false
+	past := time.Now().Add(-24 * time.Hour)
+	future := time.Now().Add(24 * time.Hour)
+
+	bundle := func(version string, preview *bool, endOfLife *time.Time) generated.ApplicationBundle {
+		return generated.ApplicationBundle{
+			Name:      "kubernetes-cluster-" + version,
+			Version:   version,
+			Preview:   preview,
+			EndOfLife: endOfLife,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		bundles []generated.ApplicationBundle
+		want    string
+	}{
+		{
+			name:    "newest",
+			bundles: []generated.ApplicationBundle{bundle("1.4.1", nil, nil), bundle("1.10.0", nil, nil), bundle("1.9.2", nil, nil)},
+			want:    "kubernetes-cluster-1.10.0",
+		},
+		{
+			name:    "preview skipped",
+			bundles: []generated.ApplicationBundle{bundle("1.4.1", &no, nil), bundle("1.5.0", &yes, nil)},
+			want:    "kubernetes-cluster-1.4.1",
+		},
+		{
+			name:    "end of life skipped",
+			bundles: []generated.ApplicationBundle{bundle("1.4.1", nil, nil), bundle("1.5.0", nil, &past)},
+			want:    "kubernetes-cluster-1.4.1",
+		},
+		{
+			name:    "deprecated selected",
+			bundles: []generated.ApplicationBundle{bundle("1.4.1", nil, nil), bundle("1.5.0", nil, &future)},
+			want:    "kubernetes-cluster-1.5.0",
+		},
+		{
+			name:    "all unsupported",
+			bundles: []generated.ApplicationBundle{bundle("1.4.1", nil, &past), bundle("1.5.0", &yes, nil)},
+		},
+		{
+			name: "none",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := latestApplicationBundle(test.bundles)
+			if test.want == "" {
+				if err == nil {
+					t.Errorf("latestApplicationBundle() = %q, want an error", got.Name)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.Name != test.want {
+				t.Errorf("latestApplicationBundle() = %q, want %q", got.Name, test.want)
+			}
+		})
+	}
+}
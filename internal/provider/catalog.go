@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/eschercloudai/eckctl/pkg/generated"
+)
+
+// clusterCatalog lists the objects a planned cluster refers to.  Several plan
+// checks need the same lists, so each is fetched at most once per plan, and
+// only when a check needs it.
+type clusterCatalog struct {
+	client *generated.ClientWithResponses
+
+	flavors       generated.OpenstackFlavors
+	flavorsErr    error
+	flavorsListed bool
+
+	images       generated.OpenstackImages
+	imagesErr    error
+	imagesListed bool
+
+	bundles       generated.ApplicationBundles
+	bundlesErr    error
+	bundlesListed bool
+
+	keyPairs       generated.OpenstackKeyPairs
+	keyPairsErr    error
+	keyPairsListed bool
+}
+
+// newClusterCatalog returns a catalog which lists objects with the client.
+func newClusterCatalog(client *generated.ClientWithResponses) *clusterCatalog {
+	return &clusterCatalog{
+		client: client,
+	}
+}
+
+// listFlavors returns the flavors available to clusters.
+func (c *clusterCatalog) listFlavors(ctx context.Context) (generated.OpenstackFlavors, error) {
+	if c.flavorsListed {
+		return c.flavors, c.flavorsErr
+	}
+
+	c.flavorsListed = true
+
+	r, err := c.client.GetApiV1ProvidersOpenstackFlavorsWithResponse(ctx)
+	if err != nil {
+		c.flavorsErr = err
+		return nil, err
+	}
+
+	if r.JSON200 == nil {
+		c.flavorsErr = newAPIError(r.StatusCode(), r.Status(), r.Body)
+		return nil, c.flavorsErr
+	}
+
+	c.flavors = *r.JSON200
+
+	return c.flavors, nil
+}
+
+// listImages returns the images available to clusters.
+func (c *clusterCatalog) listImages(ctx context.Context) (generated.OpenstackImages, error) {
+	if c.imagesListed {
+		return c.images, c.imagesErr
+	}
+
+	c.imagesListed = true
+
+	r, err := c.client.GetApiV1ProvidersOpenstackImagesWithResponse(ctx)
+	if err != nil {
+		c.imagesErr = err
+		return nil, err
+	}
+
+	if r.JSON200 == nil {
+		c.imagesErr = newAPIError(r.StatusCode(), r.Status(), r.Body)
+		return nil, c.imagesErr
+	}
+
+	c.images = *r.JSON200
+
+	return c.images, nil
+}
+
+// listClusterBundles returns the cluster application bundles.
+func (c *clusterCatalog) listClusterBundles(ctx context.Context) (generated.ApplicationBundles, error) {
+	if c.bundlesListed {
+		return c.bundles, c.bundlesErr
+	}
+
+	c.bundlesListed = true
+
+	r, err := c.client.GetApiV1ApplicationbundlesClusterWithResponse(ctx)
+	if err != nil {
+		c.bundlesErr = err
+		return nil, err
+	}
+
+	if r.JSON200 == nil {
+		c.bundlesErr = newAPIError(r.StatusCode(), r.Status(), r.Body)
+		return nil, c.bundlesErr
+	}
+
+	c.bundles = *r.JSON200
+
+	return c.bundles, nil
+}
+
+// listKeyPairs returns the SSH key pairs of the project.
+func (c *clusterCatalog) listKeyPairs(ctx context.Context) (generated.OpenstackKeyPairs, error) {
+	if c.keyPairsListed {
+		return c.keyPairs, c.keyPairsErr
+	}
+
+	c.keyPairsListed = true
+
+	r, err := c.client.GetApiV1ProvidersOpenstackKeyPairsWithResponse(ctx)
+	if err != nil {
+		c.keyPairsErr = err
+		return nil, err
+	}
+
+	if r.JSON200 == nil {
+		c.keyPairsErr = newAPIError(r.StatusCode(), r.Status(), r.Body)
+		return nil, c.keyPairsErr
+	}
+
+	c.keyPairs = *r.JSON200
+
+	return c.keyPairs, nil
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &cidrSubnetsFunction{}
+)
+
+// NewCIDRSubnetsFunction is a helper function to simplify the provider implementation.
+func NewCIDRSubnetsFunction() function.Function {
+	return &cidrSubnetsFunction{}
+}
+
+// cidrSubnetsFunction is the function implementation.
+type cidrSubnetsFunction struct{}
+
+// cidrSubnetsModel maps the function result data, which uses the attribute
+// names of the cluster network so it can be assigned to it directly.
+type cidrSubnetsModel struct {
+	NodePrefix    types.String `tfsdk:"nodeprefix"`
+	PodPrefix     types.String `tfsdk:"podprefix"`
+	ServicePrefix types.String `tfsdk:"serviceprefix"`
+}
+
+// Metadata returns the function name.
+func (f *cidrSubnetsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_subnets"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *cidrSubnetsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Split a network into cluster node, pod and service prefixes.",
+		Description: "Splits an IPv4 or IPv6 network into the non-overlapping `nodeprefix`, `podprefix` and `serviceprefix` of a cluster network.  " +
+			"Pods are given the first half of the network, services the third quarter and nodes the last quarter, so the network must have a prefix length at least 2 shorter than the address length.  " +
+			"Combine with `cidrsubnet` to give each cluster stamped from a module its own network.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "base_cidr",
+				Description: "Network to split in CIDR notation, e.g. `10.0.0.0/14`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"nodeprefix":    types.StringType,
+				"podprefix":     types.StringType,
+				"serviceprefix": types.StringType,
+			},
+		},
+	}
+}
+
+// Run splits the network.
+func (f *cidrSubnetsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var baseCIDR string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &baseCIDR))
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := cidrSubnets(baseCIDR)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid base_cidr: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, &result))
+}
+
+// cidrSubnets splits a network into pod, service and node prefixes.  Host
+// bits of the network are ignored, as with the cidrsubnet function.
+func cidrSubnets(baseCIDR string) (cidrSubnetsModel, error) {
+	base, err := netip.ParsePrefix(baseCIDR)
+	if err != nil {
+		return cidrSubnetsModel{}, err
+	}
+
+	base = base.Masked()
+
+	bits := base.Bits()
+	if bits+2 > base.Addr().BitLen() {
+		return cidrSubnetsModel{}, fmt.Errorf("network %s is too small to split, the prefix length must be at most %d", base, base.Addr().BitLen()-2)
+	}
+
+	// The pods take the first half, then the remainder is halved again for
+	// services and nodes by setting the next one or two network bits.
+	pods := netip.PrefixFrom(base.Addr(), bits+1)
+	services := netip.PrefixFrom(setAddrBit(base.Addr(), bits), bits+2)
+	nodes := netip.PrefixFrom(setAddrBit(setAddrBit(base.Addr(), bits), bits+1), bits+2)
+
+	return cidrSubnetsModel{
+		NodePrefix:    types.StringValue(nodes.String()),
+		PodPrefix:     types.StringValue(pods.String()),
+		ServicePrefix: types.StringValue(services.String()),
+	}, nil
+}
+
+// setAddrBit returns the address with the given bit, counted from the most
+// significant, set.
+func setAddrBit(addr netip.Addr, bit int) netip.Addr {
+	b := addr.AsSlice()
+	b[bit/8] |= 0x80 >> (bit % 8)
+
+	result, _ := netip.AddrFromSlice(b)
+
+	return result
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCIDRSubnetsFunction(t *testing.T) {
+	t.Parallel()
+
+	result := func(node, pod, service string) attr.Value {
+		return types.ObjectValueMust(
+			map[string]attr.Type{
+				"nodeprefix":    types.StringType,
+				"podprefix":     types.StringType,
+				"serviceprefix": types.StringType,
+			},
+			map[string]attr.Value{
+				"nodeprefix":    types.StringValue(node),
+				"podprefix":     types.StringValue(pod),
+				"serviceprefix": types.StringValue(service),
+			},
+		)
+	}
+
+	tests := []struct {
+		name     string
+		baseCIDR string
+		want     attr.Value
+		wantErr  *function.FuncError
+	}{
+		{
+			name:     "IPv4",
+			baseCIDR: "10.0.0.0/14",
+			want:     result("10.3.0.0/16", "10.0.0.0/15", "10.2.0.0/16"),
+		},
+		{
+			name:     "IPv4 unaligned",
+			baseCIDR: "192.168.0.0/22",
+			want:     result("192.168.3.0/24", "192.168.0.0/23", "192.168.2.0/24"),
+		},
+		{
+			name:     "host bits ignored",
+			baseCIDR: "10.1.2.3/8",
+			want:     result("10.192.0.0/10", "10.0.0.0/9", "10.128.0.0/10"),
+		},
+		{
+			name:     "smallest IPv4",
+			baseCIDR: "10.0.0.0/30",
+			want:     result("10.0.0.3/32", "10.0.0.0/31", "10.0.0.2/32"),
+		},
+		{
+			name:     "IPv6",
+			baseCIDR: "fd00::/48",
+			want:     result("fd00:0:0:c000::/50", "fd00::/49", "fd00:0:0:8000::/50"),
+		},
+		{
+			name:     "IPv4 too small",
+			baseCIDR: "10.0.0.0/31",
+			wantErr:  testFuncError(0, "Invalid base_cidr: network 10.0.0.0/31 is too small to split, the prefix length must be at most 30"),
+		},
+		{
+			name:     "IPv6 too small",
+			baseCIDR: "fd00::/127",
+			wantErr:  testFuncError(0, "Invalid base_cidr: network fd00::/127 is too small to split, the prefix length must be at most 126"),
+		},
+		{
+			name:     "not a prefix",
+			baseCIDR: "10.0.0.0",
+			wantErr:  testFuncError(0, `Invalid base_cidr: netip.ParsePrefix("10.0.0.0"): no '/'`),
+		},
+		{
+			name:     "empty",
+			baseCIDR: "",
+			wantErr:  testFuncError(0, `Invalid base_cidr: netip.ParsePrefix(""): no '/'`),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := testRunFunction(t, NewCIDRSubnetsFunction(), types.StringValue(test.baseCIDR))
+			if !err.Equal(test.wantErr) {
+				t.Fatalf("error = %v, want %v", err, test.wantErr)
+			}
+
+			if test.wantErr == nil && !got.Equal(test.want) {
+				t.Errorf("result = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
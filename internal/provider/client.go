@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/eschercloudai/eckctl/pkg/generated"
+)
+
+// clientConfig controls how the provider connects to the ECK API.
+type clientConfig struct {
+	// insecure disables verification of the API server certificate.
+	insecure bool
+	// caCert is a PEM encoded CA bundle trusted in addition to the system
+	// certificate pool.
+	caCert []byte
+	// clientCert and clientKey are a PEM encoded certificate and private key
+	// presented to the API server for mutual TLS.
+	clientCert []byte
+	clientKey  []byte
+	// timeout limits the time taken by each API call, including retries.
+	timeout time.Duration
+	// retry controls how transient failures are retried.
+	retry retryConfig
+	// rateLimit controls how many requests may be made.
+	rateLimit rateLimitConfig
+	// userAgent identifies the provider in ECK API logs.
+	userAgent string
+	// headers are added to every request, e.g. for API gateways which
+	// require an API key.
+	headers map[string]string
+}
+
+// defaultRequestTimeout is used when the provider does not configure a request
+// timeout.
+const defaultRequestTimeout = 2 * time.Minute
+
+// maxIdleConnsPerHost matches Terraform's default parallelism, so that
+// connections to the ECK API are reused rather than reopened when many
+// resources are managed at once.
+const maxIdleConnsPerHost = 10
+
+// regionEndpoint returns the ECK API endpoint of an EscherCloud region.
+func regionEndpoint(region string) string {
+	return "https://eck." + region + ".eschercloud.dev"
+}
+
+// newHTTPClient creates the HTTP client used for all ECK API requests,
+// including authentication.
+func newHTTPClient(config clientConfig) (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.insecure, //nolint:gosec
+	}
+
+	if len(config.caCert) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(config.caCert) {
+			return nil, errors.New("no PEM encoded certificates found in CA bundle")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(config.clientCert) > 0 {
+		certificate, err := tls.X509KeyPair(config.clientCert, config.clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+
+	return &http.Client{
+		Transport: newRetryTransport(newRateLimitTransport(newLoggingTransport(transport), config.rateLimit), config.retry),
+		Timeout:   config.timeout,
+	}, nil
+}
+
+// newClient creates an ECK API client which sends requests with the HTTP
+// client, authenticated with tokens from the token source.
+func newClient(host string, httpClient *http.Client, tokens *tokenSource, userAgent string, headers map[string]string) (*generated.ClientWithResponses, error) {
+	authClient := &http.Client{
+		Transport: newAuthTransport(httpClient.Transport, tokens),
+		Timeout:   httpClient.Timeout,
+	}
+
+	return generated.NewClientWithResponses(host,
+		generated.WithHTTPClient(authClient),
+		generated.WithRequestEditorFn(userAgentEditor(userAgent)),
+		generated.WithRequestEditorFn(headersEditor(headers)),
+	)
+}
+
+// drainBody reads the remainder of a response body and closes it, so the
+// connection can be reused for the next request.
+func drainBody(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, body)
+	body.Close()
+}
+
+// userAgentEditor sets the User-Agent of every outgoing request.
+func userAgentEditor(userAgent string) generated.RequestEditorFn {
+	return func(_ context.Context, req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+
+		return nil
+	}
+}
+
+// headersEditor sets the additional headers configured for the provider on
+// every outgoing request.
+func headersEditor(headers map[string]string) generated.RequestEditorFn {
+	return func(_ context.Context, req *http.Request) error {
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+
+		return nil
+	}
+}
+
+// editorTransport is an http.RoundTripper which applies request editors to
+// requests not made by the generated client, such as the OAuth2 password
+// grant.
+type editorTransport struct {
+	next    http.RoundTripper
+	editors []generated.RequestEditorFn
+}
+
+func newEditorTransport(next http.RoundTripper, editors ...generated.RequestEditorFn) *editorTransport {
+	return &editorTransport{
+		next:    next,
+		editors: editors,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *editorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+
+	for _, editor := range t.editors {
+		if err := editor(r.Context(), r); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.next.RoundTrip(r)
+}
@@ -2,12 +2,12 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/eschercloudai/eckctl/pkg/generated"
@@ -27,25 +27,127 @@ func NewClusterDataSource() datasource.DataSource {
 // clusterDataSource is the data source implementation.
 type clusterDataSource struct {
 	client *generated.ClientWithResponses
+	poll   pollConfig
 }
 
 // clusterModel maps clusterModel schema data.
 type clusterModel struct {
-	ApplicationBundle types.String            `tfsdk:"applicationbundle"`
-	ClusterFeatures   *clusterFeaturesModel   `tfsdk:"clusterfeatures"`
-	ClusterNetwork    *clusterNetworkModel    `tfsdk:"clusternetwork"`
-	ClusterOpenstack  *clusterOpenstackModel  `tfsdk:"clusteropenstack"`
-	ControlPlane      *controlPlaneNodesModel `tfsdk:"controlplane"`
-	EckCp             types.String            `tfsdk:"eckcp"`
-	Kubeconfig        types.String            `tfsdk:"kubeconfig"`
-	Name              types.String            `tfsdk:"name"`
-	Status            types.String            `tfsdk:"status"`
-	Wait              types.Bool              `tfsdk:"wait"`
-	WorkloadNodePools []workloadNodePoolModel `tfsdk:"workloadnodepools"`
+	Api                *clusterAPIModel         `tfsdk:"api"`
+	ApiEndpoint        types.String             `tfsdk:"api_endpoint"`
+	ApplicationBundle  types.String             `tfsdk:"applicationbundle"`
+	AutoUpgrade        *clusterAutoUpgradeModel `tfsdk:"autoupgrade"`
+	ClusterFeatures    *clusterFeaturesModel    `tfsdk:"clusterfeatures"`
+	ClusterNetwork     *clusterNetworkModel     `tfsdk:"clusternetwork"`
+	ClusterOpenstack   *clusterOpenstackModel   `tfsdk:"clusteropenstack"`
+	ControlPlane       *controlPlaneNodesModel  `tfsdk:"controlplane"`
+	CreatedAt          types.String             `tfsdk:"created_at"`
+	DeletionProtection types.Bool               `tfsdk:"deletion_protection"`
+	EckCp              types.String             `tfsdk:"eckcp"`
+	ExtraFeatures      types.Map                `tfsdk:"extra_features"`
+	Id                 types.String             `tfsdk:"id"`
+	Kubeconfig         types.String             `tfsdk:"kubeconfig"`
+	KubeconfigRotation types.String             `tfsdk:"kubeconfig_rotation"`
+	Name               types.String             `tfsdk:"name"`
+	RollingUpgrade     types.Bool               `tfsdk:"rolling_upgrade"`
+	SpecJSON           types.String             `tfsdk:"spec_json"`
+	Status             types.String             `tfsdk:"status"`
+	StoreKubeconfig    types.Bool               `tfsdk:"store_kubeconfig"`
+	Wait               types.Bool               `tfsdk:"wait"`
+	WaitForNodes       types.Bool               `tfsdk:"wait_for_nodes"`
+	WaitInterval       types.String             `tfsdk:"wait_interval"`
+	WaitTimeout        types.String             `tfsdk:"wait_timeout"`
+	WorkloadNodePools  []workloadNodePoolModel  `tfsdk:"workloadnodepools"`
+}
+
+// clusterDataSourceModel maps the cluster data source schema data, which omits
+// the resource-only provisioning settings of clusterModel.
+type clusterDataSourceModel struct {
+	Api               *clusterAPIModel                  `tfsdk:"api"`
+	ApiEndpoint       types.String                      `tfsdk:"api_endpoint"`
+	ApplicationBundle types.String                      `tfsdk:"applicationbundle"`
+	AutoUpgrade       *clusterAutoUpgradeModel          `tfsdk:"autoupgrade"`
+	ClusterFeatures   *clusterFeaturesModel             `tfsdk:"clusterfeatures"`
+	ClusterNetwork    *clusterNetworkModel              `tfsdk:"clusternetwork"`
+	ClusterOpenstack  *clusterOpenstackModel            `tfsdk:"clusteropenstack"`
+	ControlPlane      *controlPlaneNodesDataSourceModel `tfsdk:"controlplane"`
+	CreatedAt         types.String                      `tfsdk:"created_at"`
+	EckCp             types.String                      `tfsdk:"eckcp"`
+	Kubeconfig        types.String                      `tfsdk:"kubeconfig"`
+	Name              types.String                      `tfsdk:"name"`
+	Status            types.String                      `tfsdk:"status"`
+	Wait              types.Bool                        `tfsdk:"wait"`
+	WaitTimeout       types.String                      `tfsdk:"wait_timeout"`
+	WorkloadNodePools []workloadNodePoolDataSourceModel `tfsdk:"workloadnodepools"`
+}
+
+// newClusterDataSourceModel copies the API-derived attributes of a cluster
+// into the data source model.
+func newClusterDataSourceModel(m clusterModel) clusterDataSourceModel {
+	return clusterDataSourceModel{
+		Api:               m.Api,
+		ApiEndpoint:       m.ApiEndpoint,
+		ApplicationBundle: m.ApplicationBundle,
+		AutoUpgrade:       m.AutoUpgrade,
+		ClusterFeatures:   m.ClusterFeatures,
+		ClusterNetwork:    m.ClusterNetwork,
+		ClusterOpenstack:  m.ClusterOpenstack,
+		ControlPlane:      newControlPlaneNodesDataSourceModel(m.ControlPlane),
+		CreatedAt:         m.CreatedAt,
+		EckCp:             m.EckCp,
+		Kubeconfig:        m.Kubeconfig,
+		Name:              m.Name,
+		Status:            m.Status,
+		WorkloadNodePools: newWorkloadNodePoolDataSourceModels(m.WorkloadNodePools),
+	}
+}
+
+func newControlPlaneNodesDataSourceModel(m *controlPlaneNodesModel) *controlPlaneNodesDataSourceModel {
+	if m == nil {
+		return nil
+	}
+
+	return &controlPlaneNodesDataSourceModel{
+		Disk:     m.Disk,
+		Flavor:   m.Flavor,
+		Image:    m.Image,
+		Replicas: m.Replicas,
+		Version:  m.Version,
+	}
+}
+
+func newWorkloadNodePoolDataSourceModels(pools []workloadNodePoolModel) []workloadNodePoolDataSourceModel {
+	var models []workloadNodePoolDataSourceModel
+
+	for _, pool := range pools {
+		models = append(models, workloadNodePoolDataSourceModel{
+			Name:                   pool.Name,
+			Disk:                   pool.Disk,
+			Flavor:                 pool.Flavor,
+			Image:                  pool.Image,
+			Labels:                 pool.Labels,
+			Replicas:               pool.Replicas,
+			Autoscaling:            pool.Autoscaling,
+			Version:                pool.Version,
+			VolumeAvailabilityZone: pool.VolumeAvailabilityZone,
+		})
+	}
+
+	return models
+}
+
+type clusterAPIModel struct {
+	AllowedPrefixes         types.List `tfsdk:"allowed_prefixes"`
+	SubjectAlternativeNames types.List `tfsdk:"subject_alternative_names"`
+}
+
+type clusterAutoUpgradeModel struct {
+	Enabled    types.Bool       `tfsdk:"enabled"`
+	DaysOfWeek *daysOfWeekModel `tfsdk:"days_of_week"`
 }
 
 type clusterFeaturesModel struct {
 	Autoscaling types.Bool `tfsdk:"autoscaling"`
+	CertManager types.Bool `tfsdk:"certmanager"`
 	Ingress     types.Bool `tfsdk:"ingress"`
 	Longhorn    types.Bool `tfsdk:"longhorn"`
 	Prometheus  types.Bool `tfsdk:"prometheus"`
@@ -53,6 +155,17 @@ type clusterFeaturesModel struct {
 }
 
 type controlPlaneNodesModel struct {
+	Disk            types.Int64  `tfsdk:"disk"`
+	Flavor          types.String `tfsdk:"flavor"`
+	Image           types.String `tfsdk:"image"`
+	ImageAutoSelect types.Bool   `tfsdk:"image_auto_select"`
+	Replicas        types.Int64  `tfsdk:"replicas"`
+	Version         types.String `tfsdk:"version"`
+}
+
+// controlPlaneNodesDataSourceModel maps the control plane of the cluster data
+// source, which omits the resource-only settings of controlPlaneNodesModel.
+type controlPlaneNodesDataSourceModel struct {
 	Disk     types.Int64  `tfsdk:"disk"`
 	Flavor   types.String `tfsdk:"flavor"`
 	Image    types.String `tfsdk:"image"`
@@ -61,14 +174,30 @@ type controlPlaneNodesModel struct {
 }
 
 type workloadNodePoolModel struct {
-	Name        types.String      `tfsdk:"name"`
-	Disk        types.Int64       `tfsdk:"disk"`
-	Flavor      types.String      `tfsdk:"flavor"`
-	Image       types.String      `tfsdk:"image"`
-	Labels      types.Map         `tfsdk:"labels"`
-	Replicas    types.Int64       `tfsdk:"replicas"`
-	Autoscaling *autoscalingModel `tfsdk:"autoscaling"`
-	Version     types.String      `tfsdk:"version"`
+	Name                   types.String      `tfsdk:"name"`
+	Disk                   types.Int64       `tfsdk:"disk"`
+	Flavor                 types.String      `tfsdk:"flavor"`
+	Image                  types.String      `tfsdk:"image"`
+	ImageAutoSelect        types.Bool        `tfsdk:"image_auto_select"`
+	Labels                 types.Map         `tfsdk:"labels"`
+	Replicas               types.Int64       `tfsdk:"replicas"`
+	Autoscaling            *autoscalingModel `tfsdk:"autoscaling"`
+	Version                types.String      `tfsdk:"version"`
+	VolumeAvailabilityZone types.String      `tfsdk:"volumeaz"`
+}
+
+// workloadNodePoolDataSourceModel maps a workload pool of the cluster data
+// source, which omits the resource-only settings of workloadNodePoolModel.
+type workloadNodePoolDataSourceModel struct {
+	Name                   types.String      `tfsdk:"name"`
+	Disk                   types.Int64       `tfsdk:"disk"`
+	Flavor                 types.String      `tfsdk:"flavor"`
+	Image                  types.String      `tfsdk:"image"`
+	Labels                 types.Map         `tfsdk:"labels"`
+	Replicas               types.Int64       `tfsdk:"replicas"`
+	Autoscaling            *autoscalingModel `tfsdk:"autoscaling"`
+	Version                types.String      `tfsdk:"version"`
+	VolumeAvailabilityZone types.String      `tfsdk:"volumeaz"`
 }
 
 type autoscalingModel struct {
@@ -96,17 +225,18 @@ func (d *clusterDataSource) Configure(_ context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*generated.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *Client, got: %T with value of %v. Please report this issue to the provider developers.", req.ProviderData, req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T with value of %v. Please report this issue to the provider developers.", req.ProviderData, req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
+	d.poll = data.poll
 }
 
 // Metadata returns the data source type name.
@@ -130,6 +260,28 @@ func (d *clusterDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 				Computed:    true,
 				Description: "The provisioning status of the cluster.",
 			},
+			"wait": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to wait for the cluster to be provisioned, e.g. when it is created by another configuration, so that its kubeconfig is available.",
+			},
+			"wait_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long to wait for the cluster to be provisioned before giving up, e.g. `10m`.  Defaults to `10m`.",
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
+			"autoupgrade": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "Automatic upgrades of the cluster's application bundle.",
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						Computed:    true,
+						Description: "Whether the application bundle is upgraded automatically.",
+					},
+					"days_of_week": daysOfWeekDataSourceAttribute(),
+				},
+			},
 			"eckcp": schema.StringAttribute{
 				Required:    true,
 				Description: "The associated ECK Control Plane for the cluster.",
@@ -138,12 +290,36 @@ func (d *clusterDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 				Computed:    true,
 				Description: "The kubeconfig for the cluster.",
 			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the cluster was created, in RFC 3339 format.",
+			},
+			"api_endpoint": schema.StringAttribute{
+				Computed:    true,
+				Description: "The URL of the Kubernetes API of the cluster, taken from the kubeconfig.  Null until the cluster is provisioned.",
+			},
+			"api": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "Options for the Kubernetes API endpoint of the cluster.",
+				Attributes: map[string]schema.Attribute{
+					"allowed_prefixes": schema.ListAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+						Description: "CIDR-formatted IP address ranges allowed to access the Kubernetes API.",
+					},
+					"subject_alternative_names": schema.ListAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+						Description: "Additional names added to the Kubernetes API server certificate.",
+					},
+				},
+			},
 			"controlplane": schema.SingleNestedAttribute{
 				Computed: true,
 				Attributes: map[string]schema.Attribute{
 					"disk": schema.Int64Attribute{
 						Computed:    true,
-						Description: "Whether to use a dedicated persistent volume for control plane nodes. It is recommended to leave this unchecked, as ephemeral storage provides higher performance for Kubernetes' etcd database. If left unset, the default ephemeral storage size of 20GB is used.",
+						Description: "Size in GiB of a dedicated persistent volume for control plane nodes. It is recommended to leave this unset, as ephemeral storage provides higher performance for Kubernetes' etcd database. If left unset, the default ephemeral storage size of 20GB is used.",
 					},
 					"flavor": schema.StringAttribute{
 						Computed:    true,
@@ -220,6 +396,11 @@ func (d *clusterDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 						Computed:    true,
 						Description: "Whether to deploy the NGINX Ingress Controller.",
 					},
+					"certmanager": schema.BoolAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "Whether to deploy cert-manager for issuing TLS certificates.",
+					},
 					"longhorn": schema.BoolAttribute{
 						Optional:    true,
 						Computed:    true,
@@ -271,6 +452,10 @@ func (d *clusterDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 							Computed:    true,
 							Description: "The version of Kubernetes.  Must match the version bundled with the OS image.",
 						},
+						"volumeaz": schema.StringAttribute{
+							Computed:    true,
+							Description: "OpenStack Cinder Availability Zone for the node disks in this pool.",
+						},
 						"autoscaling": schema.SingleNestedAttribute{
 							Computed:    true,
 							Description: "Configuration options for the autoscaler.",
@@ -294,46 +479,67 @@ func (d *clusterDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 
 // Read refreshes the Terraform state with the latest data.
 func (d *clusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state clusterModel
+	var config clusterDataSourceModel
 
-	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	r, err := d.client.GetApiV1ControlplanesControlPlaneNameClustersClusterName(ctx, state.EckCp.ValueString(), state.Name.ValueString())
+	state := clusterModel{
+		Name:            config.Name,
+		EckCp:           config.EckCp,
+		ClusterFeatures: &clusterFeaturesModel{},
+	}
+
+	cluster, err := getCluster(ctx, d.client, state.EckCp.ValueString(), state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to retrieve cluster information",
-			err.Error(),
+			"Could not read cluster "+state.Name.ValueString()+": "+err.Error(),
 		)
 		return
 	}
 
-	if r.StatusCode != http.StatusOK {
-		fmt.Printf("Error retrieving cluster information, %v", r.StatusCode)
-		return
-	}
+	provisioned := cluster.Status != nil && cluster.Status.Status == "Provisioned"
+	if !provisioned && config.Wait.ValueBool() {
+		timeout := 10 * time.Minute
+		if !config.WaitTimeout.IsNull() {
+			timeout, _ = time.ParseDuration(config.WaitTimeout.ValueString())
+		}
 
-	cluster := generated.KubernetesCluster{}
-	err = json.NewDecoder(r.Body).Decode(&cluster)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to read cluster information",
-			"An error occurred while parsing the response from the ECK API."+
-				"JSON Error: "+err.Error(),
-		)
+		cluster, err = waitForResourceToBeReady(ctx, d.client, state.EckCp.ValueString(), state.Name.ValueString(), d.poll, timeout)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Waiting for Cluster to be Ready",
+				"Could not wait for cluster "+state.Name.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+
+		provisioned = true
 	}
 
 	var kubeconfig string
-	if cluster.Status.Status == "Provisioned" {
-		kubeconfig = getKubeconfig(*d.client, ctx, state.EckCp.ValueString(), cluster.Name)
-	} else {
-		kubeconfig = ""
+	if provisioned {
+		kubeconfig, err = getKubeconfig(ctx, d.client, state.EckCp.ValueString(), cluster.Name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to retrieve kubeconfig",
+				"Could not read kubeconfig for cluster "+cluster.Name+": "+err.Error(),
+			)
+			return
+		}
 	}
 
 	// Map response body to model
-	state = generateClusterModel(ctx, cluster, state.EckCp.ValueString(), string(kubeconfig), state.Wait.ValueBool())
+	state = generateClusterModel(ctx, *cluster, kubeconfig, state)
+	model := newClusterDataSourceModel(state)
+	model.Wait = config.Wait
+	model.WaitTimeout = config.WaitTimeout
 
 	// Set state
-	diags := resp.State.Set(ctx, &state)
+	diags := resp.State.Set(ctx, &model)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
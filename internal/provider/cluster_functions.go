@@ -2,23 +2,84 @@ package provider
 
 import (
 	"context"
-	"io"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/eschercloudai/eckctl/pkg/generated"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
-func getKubeconfig(client generated.ClientWithResponses, ctx context.Context, eckcp string, cluster string) string {
-	k, err := client.GetApiV1ControlplanesControlPlaneNameClustersClusterNameKubeconfig(ctx, eckcp, cluster)
+// getCluster fetches a cluster from the ECK API.
+func getCluster(ctx context.Context, client *generated.ClientWithResponses, eckcp string, cluster string) (*generated.KubernetesCluster, error) {
+	r, err := client.GetApiV1ControlplanesControlPlaneNameClustersClusterNameWithResponse(ctx, eckcp, cluster)
 	if err != nil {
-		return ""
+		return nil, err
 	}
-	kc, err := io.ReadAll(k.Body)
+
+	if r.JSON200 == nil {
+		return nil, newAPIError(r.StatusCode(), r.Status(), r.Body)
+	}
+
+	return r.JSON200, nil
+}
+
+// getKubeconfig fetches the kubeconfig of a provisioned cluster from the ECK
+// API.
+func getKubeconfig(ctx context.Context, client *generated.ClientWithResponses, eckcp string, cluster string) (string, error) {
+	r, err := client.GetApiV1ControlplanesControlPlaneNameClustersClusterNameKubeconfigWithResponse(ctx, eckcp, cluster)
 	if err != nil {
-		return ""
+		return "", err
+	}
+
+	if r.StatusCode() != http.StatusOK {
+		return "", newAPIError(r.StatusCode(), r.Status(), r.Body)
 	}
-	return string(kc)
+
+	return string(r.Body), nil
+}
+
+// compareKubernetesVersions returns -1, 0 or 1 if a is older than, the same as
+// or newer than b.
+func compareKubernetesVersions(a string, b string) (int, error) {
+	av, err := parseKubernetesVersion(a)
+	if err != nil {
+		return 0, err
+	}
+
+	bv, err := parseKubernetesVersion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range av {
+		switch {
+		case av[i] < bv[i]:
+			return -1, nil
+		case av[i] > bv[i]:
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// parseKubernetesVersion returns the minor and patch components of a version
+// such as v1.28.3.
+func parseKubernetesVersion(version string) ([2]int, error) {
+	matches := kubernetesVersionPattern.FindStringSubmatch(version)
+	if matches == nil {
+		return [2]int{}, fmt.Errorf("invalid Kubernetes version %q", version)
+	}
+
+	minor, _ := strconv.Atoi(matches[1])
+	patch, _ := strconv.Atoi(matches[2])
+
+	return [2]int{minor, patch}, nil
 }
 
 func tfMapToStringMap(ctx context.Context, value basetypes.MapValue) (*map[string]string, error) {
@@ -38,10 +99,46 @@ func tfMapToStringMap(ctx context.Context, value basetypes.MapValue) (*map[strin
 	return &mapVal, nil
 }
 
+// tfListToStringSlice converts a list of strings, returning nil if the list
+// is null so the field is omitted from API requests.
+func tfListToStringSlice(ctx context.Context, value basetypes.ListValue) *[]string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	var values []string
+	value.ElementsAs(ctx, &values, false)
+
+	return &values
+}
+
+// stringSliceToTfList is the inverse of tfListToStringSlice.
+func stringSliceToTfList(ctx context.Context, values *[]string) types.List {
+	if values == nil {
+		return types.ListNull(types.StringType)
+	}
+
+	list, _ := types.ListValueFrom(ctx, types.StringType, *values)
+
+	return list
+}
+
 func generateKubernetesCluster(ctx context.Context, plan clusterModel) generated.KubernetesCluster {
 	var dnsNameservers []string
 	plan.ClusterNetwork.DnsNameservers.ElementsAs(ctx, &dnsNameservers, false)
 	workloadNodePools := generateWorkloadNodePools(ctx, plan.WorkloadNodePools)
+	// Pools without a version run the control plane's version.
+	for i := range workloadNodePools {
+		if workloadNodePools[i].Machine.Version == "" {
+			workloadNodePools[i].Machine.Version = plan.ControlPlane.Version.ValueString()
+		}
+	}
+	var controlPlaneDisk *generated.OpenstackVolume
+	if !plan.ControlPlane.Disk.IsNull() && !plan.ControlPlane.Disk.IsUnknown() {
+		controlPlaneDisk = &generated.OpenstackVolume{
+			Size: int(plan.ControlPlane.Disk.ValueInt64()),
+		}
+	}
 	cluster := generated.KubernetesCluster{
 		Name: plan.Name.ValueString(),
 		Status: &generated.KubernetesResourceStatus{
@@ -52,6 +149,7 @@ func generateKubernetesCluster(ctx context.Context, plan clusterModel) generated
 			Version: plan.ApplicationBundle.ValueString(),
 		},
 		ControlPlane: generated.OpenstackMachinePool{
+			Disk:       controlPlaneDisk,
 			ImageName:  plan.ControlPlane.Image.ValueString(),
 			FlavorName: plan.ControlPlane.Flavor.ValueString(),
 			Replicas:   int(plan.ControlPlane.Replicas.ValueInt64()),
@@ -63,40 +161,176 @@ func generateKubernetesCluster(ctx context.Context, plan clusterModel) generated
 			ServicePrefix:  plan.ClusterNetwork.ServicePrefix.ValueString(),
 			PodPrefix:      plan.ClusterNetwork.PodPrefix.ValueString(),
 		},
-		Openstack: generated.KubernetesClusterOpenStack{
-			ExternalNetworkID:       plan.ClusterOpenstack.ExternalNetworkID.ValueString(),
-			ComputeAvailabilityZone: plan.ClusterOpenstack.ComputeAvailabilityZone.ValueString(),
-			VolumeAvailabilityZone:  plan.ClusterOpenstack.VolumeAvailabilityZone.ValueString(),
-			SshKeyName:              plan.ClusterOpenstack.SshKeyName.ValueStringPointer(),
-		},
-		Features: &generated.KubernetesClusterFeatures{
+		WorkloadPools: workloadNodePools,
+	}
+
+	if plan.ClusterFeatures != nil {
+		cluster.Features = &generated.KubernetesClusterFeatures{
 			Autoscaling:         plan.ClusterFeatures.Autoscaling.ValueBoolPointer(),
 			Ingress:             plan.ClusterFeatures.Ingress.ValueBoolPointer(),
+			CertManager:         plan.ClusterFeatures.CertManager.ValueBoolPointer(),
 			FileStorage:         plan.ClusterFeatures.Longhorn.ValueBoolPointer(),
 			Prometheus:          plan.ClusterFeatures.Prometheus.ValueBoolPointer(),
 			KubernetesDashboard: plan.ClusterFeatures.Dashboard.ValueBoolPointer(),
-		},
-		WorkloadPools: workloadNodePools,
+		}
+	}
+
+	// Clusters without OpenStack settings are private, and placed in the
+	// default availability zones.
+	cluster.Openstack = generated.KubernetesClusterOpenStack{
+		ComputeAvailabilityZone: defaultAvailabilityZone,
+		VolumeAvailabilityZone:  defaultAvailabilityZone,
+	}
+
+	if plan.ClusterOpenstack != nil {
+		cluster.Openstack = generated.KubernetesClusterOpenStack{
+			ExternalNetworkID:       plan.ClusterOpenstack.ExternalNetworkID.ValueString(),
+			ComputeAvailabilityZone: plan.ClusterOpenstack.ComputeAvailabilityZone.ValueString(),
+			VolumeAvailabilityZone:  plan.ClusterOpenstack.VolumeAvailabilityZone.ValueString(),
+			SshKeyName:              plan.ClusterOpenstack.SshKeyName.ValueStringPointer(),
+		}
+	}
+
+	if plan.AutoUpgrade != nil && plan.AutoUpgrade.Enabled.ValueBool() {
+		cluster.ApplicationBundleAutoUpgrade = &generated.ApplicationBundleAutoUpgrade{
+			DaysOfWeek: generateDaysOfWeek(plan.AutoUpgrade.DaysOfWeek),
+		}
+	}
+
+	if plan.Api != nil {
+		cluster.Api = &generated.KubernetesClusterAPI{
+			AllowedPrefixes:         tfListToStringSlice(ctx, plan.Api.AllowedPrefixes),
+			SubjectAlternativeNames: tfListToStringSlice(ctx, plan.Api.SubjectAlternativeNames),
+		}
 	}
 
 	return cluster
 
 }
 
-func generateClusterModel(ctx context.Context, cluster generated.KubernetesCluster, eckcp string, kubeconfig string, wait bool) clusterModel {
+// kubeconfigServer returns the Kubernetes API server of a kubeconfig, which is
+// null when the kubeconfig is empty or cannot be decoded.
+func kubeconfigServer(kubeconfig string) types.String {
+	if kubeconfig == "" {
+		return types.StringNull()
+	}
+
+	decoded, err := decodeKubeconfig(kubeconfig)
+	if err != nil {
+		return types.StringNull()
+	}
+
+	return decoded.Host
+}
+
+// creationTime returns the creation time of a resource in RFC 3339 format,
+// which is null when the API has not reported it.
+func creationTime(status *generated.KubernetesResourceStatus) types.String {
+	if status == nil || status.CreationTime.IsZero() {
+		return types.StringNull()
+	}
+
+	return types.StringValue(status.CreationTime.Format(time.RFC3339))
+}
+
+// resourceStatus returns the provisioning status of a resource, or null if the
+// API has not reported one.
+func resourceStatus(status *generated.KubernetesResourceStatus) types.String {
+	if status == nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(status.Status)
+}
+
+// validKubeconfig reports whether a stored kubeconfig is present and can be
+// decoded, in which case it is kept rather than fetched again.
+func validKubeconfig(kubeconfig types.String) bool {
+	if kubeconfig.IsNull() || kubeconfig.IsUnknown() || kubeconfig.ValueString() == "" {
+		return false
+	}
+
+	_, err := decodeKubeconfig(kubeconfig.ValueString())
+
+	return err == nil
+}
+
+// clusterID returns the identifier of a cluster, which is unique across control
+// planes.
+func clusterID(eckcp string, name string) string {
+	return eckcp + "/" + name
+}
+
+// generateClusterModel renders the API representation of a cluster for
+// Terraform state.  Settings which only exist in Terraform, such as the control
+// plane name and wait options, are carried over from prior.
+func generateClusterModel(ctx context.Context, cluster generated.KubernetesCluster, kubeconfig string, prior clusterModel) clusterModel {
 	ns, _ := types.ListValueFrom(ctx, types.StringType, cluster.Network.DnsNameservers)
+	controlPlaneDisk := types.Int64Null()
+	if cluster.ControlPlane.Disk != nil {
+		controlPlaneDisk = types.Int64Value(int64(cluster.ControlPlane.Disk.Size))
+	}
+	// The creation time is only known once the API has accepted the cluster.
+	createdAt := creationTime(cluster.Status)
+	if createdAt.IsNull() && !prior.CreatedAt.IsUnknown() {
+		createdAt = prior.CreatedAt
+	}
+	// State written before wait_for_nodes and rolling_upgrade existed has no
+	// value for them.
+	waitForNodes := prior.WaitForNodes
+	if waitForNodes.IsNull() {
+		waitForNodes = types.BoolValue(false)
+	}
+	rollingUpgrade := prior.RollingUpgrade
+	if rollingUpgrade.IsNull() {
+		rollingUpgrade = types.BoolValue(false)
+	}
+	// The stored kubeconfig is kept unless a new one was fetched, as the API
+	// may generate a different kubeconfig each time it is requested.
+	kubeconfigValue := types.StringValue(kubeconfig)
+	if kubeconfig == "" && !prior.Kubeconfig.IsNull() && !prior.Kubeconfig.IsUnknown() {
+		kubeconfigValue = prior.Kubeconfig
+	}
+	apiEndpoint := kubeconfigServer(kubeconfigValue.ValueString())
+	// State written before store_kubeconfig existed always stored it.
+	storeKubeconfig := prior.StoreKubeconfig
+	if storeKubeconfig.IsNull() {
+		storeKubeconfig = types.BoolValue(true)
+	}
+	if !storeKubeconfig.ValueBool() {
+		// Only the API endpoint is recorded, keeping credentials out of state.
+		apiEndpoint = kubeconfigServer(kubeconfig)
+		if kubeconfig == "" && !prior.ApiEndpoint.IsUnknown() {
+			apiEndpoint = prior.ApiEndpoint
+		}
+		kubeconfigValue = types.StringNull()
+	}
 	clusterModel := clusterModel{
-		Name:              types.StringValue(cluster.Name),
-		ApplicationBundle: types.StringValue(cluster.ApplicationBundle.Name),
-		Status:            types.StringValue(cluster.Status.Status),
-		EckCp:             types.StringValue(eckcp),
-		Kubeconfig:        types.StringValue(kubeconfig),
-		Wait:              types.BoolValue(wait),
+		ApiEndpoint:        apiEndpoint,
+		CreatedAt:          createdAt,
+		Name:               types.StringValue(cluster.Name),
+		ApplicationBundle:  types.StringValue(cluster.ApplicationBundle.Name),
+		Status:             resourceStatus(cluster.Status),
+		DeletionProtection: prior.DeletionProtection,
+		EckCp:              prior.EckCp,
+		Id:                 types.StringValue(clusterID(prior.EckCp.ValueString(), cluster.Name)),
+		ExtraFeatures:      prior.ExtraFeatures,
+		Kubeconfig:         kubeconfigValue,
+		KubeconfigRotation: prior.KubeconfigRotation,
+		RollingUpgrade:     rollingUpgrade,
+		SpecJSON:           prior.SpecJSON,
+		StoreKubeconfig:    storeKubeconfig,
+		Wait:               prior.Wait,
+		WaitForNodes:       waitForNodes,
+		WaitInterval:       prior.WaitInterval,
+		WaitTimeout:        prior.WaitTimeout,
 		ControlPlane: &controlPlaneNodesModel{
-			Flavor:   types.StringValue(cluster.ControlPlane.FlavorName),
-			Image:    types.StringValue(cluster.ControlPlane.ImageName),
-			Replicas: types.Int64Value(int64(cluster.ControlPlane.Replicas)),
-			Version:  types.StringValue(cluster.ControlPlane.Version),
+			Disk:            controlPlaneDisk,
+			Flavor:          types.StringValue(cluster.ControlPlane.FlavorName),
+			Image:           types.StringValue(cluster.ControlPlane.ImageName),
+			ImageAutoSelect: types.BoolValue(false),
+			Replicas:        types.Int64Value(int64(cluster.ControlPlane.Replicas)),
+			Version:         types.StringValue(cluster.ControlPlane.Version),
 		},
 		ClusterNetwork: &clusterNetworkModel{
 			DnsNameservers: ns,
@@ -104,24 +338,104 @@ func generateClusterModel(ctx context.Context, cluster generated.KubernetesClust
 			PodPrefix:      types.StringValue(cluster.Network.PodPrefix),
 			ServicePrefix:  types.StringValue(cluster.Network.ServicePrefix),
 		},
-		ClusterOpenstack: &clusterOpenstackModel{
-			ComputeAvailabilityZone: types.StringValue(cluster.Openstack.ComputeAvailabilityZone),
-			VolumeAvailabilityZone:  types.StringValue(cluster.Openstack.VolumeAvailabilityZone),
-			ExternalNetworkID:       types.StringValue(cluster.Openstack.ExternalNetworkID),
-			SshKeyName:              types.StringPointerValue(cluster.Openstack.SshKeyName),
-		},
-		ClusterFeatures: &clusterFeaturesModel{
-			Autoscaling: types.BoolValue(*cluster.Features.Autoscaling),
-			Longhorn:    types.BoolValue(*cluster.Features.FileStorage),
-			Ingress:     types.BoolValue(*cluster.Features.Ingress),
-			Prometheus:  types.BoolValue(*cluster.Features.Prometheus),
-			Dashboard:   types.BoolValue(*cluster.Features.KubernetesDashboard),
-		},
+		ClusterOpenstack:  generateClusterOpenstackModel(cluster.Openstack, prior.ClusterOpenstack),
+		ClusterFeatures:   generateClusterFeaturesModel(cluster.Features, prior.ClusterFeatures),
 		WorkloadNodePools: generateWorkloadNodePoolModel(ctx, cluster.WorkloadPools),
 	}
+	// Whether images are selected automatically is not known to the API.
+	if prior.ControlPlane != nil && !prior.ControlPlane.ImageAutoSelect.IsNull() {
+		clusterModel.ControlPlane.ImageAutoSelect = prior.ControlPlane.ImageAutoSelect
+	}
+	for i, pool := range clusterModel.WorkloadNodePools {
+		for _, priorPool := range prior.WorkloadNodePools {
+			if priorPool.Name.Equal(pool.Name) && !priorPool.ImageAutoSelect.IsNull() {
+				clusterModel.WorkloadNodePools[i].ImageAutoSelect = priorPool.ImageAutoSelect
+			}
+		}
+	}
+	if cluster.ApplicationBundleAutoUpgrade != nil {
+		clusterModel.AutoUpgrade = &clusterAutoUpgradeModel{
+			Enabled:    types.BoolValue(true),
+			DaysOfWeek: generateDaysOfWeekModel(cluster.ApplicationBundleAutoUpgrade.DaysOfWeek),
+		}
+	} else if prior.AutoUpgrade != nil {
+		// Disabled upgrades are omitted by the API, so keep the configured
+		// windows to avoid a spurious diff.
+		clusterModel.AutoUpgrade = &clusterAutoUpgradeModel{
+			Enabled:    types.BoolValue(false),
+			DaysOfWeek: prior.AutoUpgrade.DaysOfWeek,
+		}
+	}
+	if cluster.Api != nil {
+		clusterModel.Api = &clusterAPIModel{
+			AllowedPrefixes:         stringSliceToTfList(ctx, cluster.Api.AllowedPrefixes),
+			SubjectAlternativeNames: stringSliceToTfList(ctx, cluster.Api.SubjectAlternativeNames),
+		}
+	}
 	return clusterModel
 }
 
+// defaultAvailabilityZone is the compute and volume availability zone of
+// clusters which do not choose one.
+const defaultAvailabilityZone = "nova"
+
+// generateClusterOpenstackModel renders the OpenStack settings of a cluster
+// for Terraform state.  If they were not configured, they stay unset unless
+// they differ from those of a private cluster in the default availability
+// zones, in which case they are surfaced as drift.
+func generateClusterOpenstackModel(openstack generated.KubernetesClusterOpenStack, prior *clusterOpenstackModel) *clusterOpenstackModel {
+	defaultZone := func(zone string) bool {
+		return zone == "" || zone == defaultAvailabilityZone
+	}
+
+	if prior == nil && openstack.ExternalNetworkID == "" && openstack.SshKeyName == nil &&
+		defaultZone(openstack.ComputeAvailabilityZone) && defaultZone(openstack.VolumeAvailabilityZone) {
+		return nil
+	}
+
+	model := &clusterOpenstackModel{
+		ComputeAvailabilityZone: types.StringValue(openstack.ComputeAvailabilityZone),
+		VolumeAvailabilityZone:  types.StringValue(openstack.VolumeAvailabilityZone),
+		ExternalNetworkID:       types.StringNull(),
+		SshKeyName:              types.StringPointerValue(openstack.SshKeyName),
+	}
+
+	if openstack.ExternalNetworkID != "" {
+		model.ExternalNetworkID = types.StringValue(openstack.ExternalNetworkID)
+	}
+
+	return model
+}
+
+// generateClusterFeaturesModel renders the cluster features for Terraform
+// state.  Features omitted by the API are disabled.  If features were not
+// configured, they stay unset unless something has been enabled out-of-band,
+// in which case it is surfaced as drift.
+func generateClusterFeaturesModel(features *generated.KubernetesClusterFeatures, prior *clusterFeaturesModel) *clusterFeaturesModel {
+	if features == nil {
+		features = &generated.KubernetesClusterFeatures{}
+	}
+
+	if prior == nil && !isEnabled(features.Autoscaling) && !isEnabled(features.FileStorage) && !isEnabled(features.Ingress) &&
+		!isEnabled(features.CertManager) && !isEnabled(features.Prometheus) && !isEnabled(features.KubernetesDashboard) {
+		return nil
+	}
+
+	return &clusterFeaturesModel{
+		Autoscaling: types.BoolValue(isEnabled(features.Autoscaling)),
+		Longhorn:    types.BoolValue(isEnabled(features.FileStorage)),
+		Ingress:     types.BoolValue(isEnabled(features.Ingress)),
+		CertManager: types.BoolValue(isEnabled(features.CertManager)),
+		Prometheus:  types.BoolValue(isEnabled(features.Prometheus)),
+		Dashboard:   types.BoolValue(isEnabled(features.KubernetesDashboard)),
+	}
+}
+
+// isEnabled treats an omitted feature flag as disabled.
+func isEnabled(flag *bool) bool {
+	return flag != nil && *flag
+}
+
 func generateWorkloadNodePools(ctx context.Context, pools []workloadNodePoolModel) generated.KubernetesClusterWorkloadPools {
 	var workloadNodePools generated.KubernetesClusterWorkloadPools
 	for _, pool := range pools {
@@ -129,7 +443,8 @@ func generateWorkloadNodePools(ctx context.Context, pools []workloadNodePoolMode
 			Name: pool.Name.ValueString(),
 			Machine: generated.OpenstackMachinePool{
 				Disk: &generated.OpenstackVolume{
-					Size: int(pool.Disk.ValueInt64()),
+					Size:             int(pool.Disk.ValueInt64()),
+					AvailabilityZone: pool.VolumeAvailabilityZone.ValueStringPointer(),
 				},
 				FlavorName: pool.Flavor.ValueString(),
 				ImageName:  pool.Image.ValueString(),
@@ -154,17 +469,165 @@ func generateWorkloadNodePools(ctx context.Context, pools []workloadNodePoolMode
 	return workloadNodePools
 }
 
+// poolLabel is set by the provider on the nodes of each workload pool to the
+// name of the pool.  Node names and Cluster API annotations are derived from
+// hashes, so without it nodes cannot be matched to their pool.
+const poolLabel = "eck.eschercloud.ai/pool"
+
+// labelPools sets poolLabel on the workload pools of a cluster request.  The
+// machines of a pool are replaced when its labels change, so pools which
+// already exist, as reported by current, are only labelled if they already
+// were or their machines are being replaced anyway.  current is nil when the
+// cluster is being created.
+func labelPools(cluster *generated.KubernetesCluster, current *generated.KubernetesCluster) {
+	existing := map[string]generated.KubernetesClusterWorkloadPool{}
+	if current != nil {
+		for _, pool := range current.WorkloadPools {
+			existing[pool.Name] = pool
+		}
+	}
+
+	for i := range cluster.WorkloadPools {
+		pool := &cluster.WorkloadPools[i]
+
+		if prior, ok := existing[pool.Name]; ok && !poolLabelled(prior) {
+			replaced := pool.Machine.FlavorName != prior.Machine.FlavorName ||
+				pool.Machine.ImageName != prior.Machine.ImageName ||
+				pool.Machine.Version != prior.Machine.Version
+			if !replaced {
+				continue
+			}
+		}
+
+		labels := map[string]string{}
+		if pool.Labels != nil {
+			for k, v := range *pool.Labels {
+				labels[k] = v
+			}
+		}
+
+		labels[poolLabel] = pool.Name
+		pool.Labels = &labels
+	}
+}
+
+// poolLabelled reports whether the nodes of a workload pool are labelled with
+// the name of the pool.
+func poolLabelled(pool generated.KubernetesClusterWorkloadPool) bool {
+	if pool.Labels == nil {
+		return false
+	}
+
+	_, ok := (*pool.Labels)[poolLabel]
+
+	return ok
+}
+
+// planPoolVersions plans the versions of workload pools which do not configure
+// them as the control plane version, so single-version clusters need only set
+// it once.  It reports whether the plan was changed.
+func planPoolVersions(plan *clusterModel) bool {
+	if plan.ControlPlane == nil || plan.ControlPlane.Version.IsUnknown() {
+		return false
+	}
+
+	changed := false
+
+	for i := range plan.WorkloadNodePools {
+		pool := &plan.WorkloadNodePools[i]
+
+		if pool.Version.IsUnknown() {
+			pool.Version = plan.ControlPlane.Version
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// planAutoscaledReplicas plans the replicas of autoscaled workload pools which
+// do not configure them.  Existing pools keep the replica count chosen by the
+// autoscaler, within any new minimum and maximum, so it is not scaled back on
+// every apply, and new pools start at their minimum.  It reports whether the
+// plan was changed, and warns when a configured replica count would override
+// the autoscaler.
+func planAutoscaledReplicas(plan *clusterModel, state *clusterModel, diags *diag.Diagnostics) bool {
+	changed := false
+
+	for i := range plan.WorkloadNodePools {
+		pool := &plan.WorkloadNodePools[i]
+		if pool.Autoscaling == nil {
+			continue
+		}
+
+		current := types.Int64Null()
+		if state != nil {
+			for _, statePool := range state.WorkloadNodePools {
+				if statePool.Name.Equal(pool.Name) && statePool.Autoscaling != nil {
+					current = statePool.Replicas
+				}
+			}
+		}
+
+		if !pool.Replicas.IsUnknown() {
+			if !current.IsNull() && !pool.Replicas.IsNull() && !current.Equal(pool.Replicas) {
+				diags.AddAttributeWarning(
+					path.Root("workloadnodepools").AtListIndex(i).AtName("replicas"),
+					"Autoscaled Replicas Will Be Reset",
+					fmt.Sprintf("Workload pool %q is autoscaled, but applying will scale it from %d to the configured %d replicas.  "+
+						"Remove replicas from the pool to keep the replica count chosen by the autoscaler.",
+						pool.Name.ValueString(), current.ValueInt64(), pool.Replicas.ValueInt64()),
+				)
+			}
+
+			continue
+		}
+
+		if !current.IsNull() {
+			pool.Replicas = clampReplicas(current, pool.Autoscaling)
+		} else {
+			pool.Replicas = pool.Autoscaling.MinimumReplicas
+		}
+
+		changed = true
+	}
+
+	return changed
+}
+
+// clampReplicas limits a replica count chosen by the autoscaler to a pool's
+// autoscaling range, which may have been changed since.
+func clampReplicas(replicas types.Int64, autoscaling *autoscalingModel) types.Int64 {
+	minimum, maximum := autoscaling.MinimumReplicas, autoscaling.MaximumReplicas
+
+	if !minimum.IsNull() && !minimum.IsUnknown() && replicas.ValueInt64() < minimum.ValueInt64() {
+		return minimum
+	}
+
+	if !maximum.IsNull() && !maximum.IsUnknown() && replicas.ValueInt64() > maximum.ValueInt64() {
+		return maximum
+	}
+
+	return replicas
+}
+
 // Render cluster workloadpool representation for Terraform state
 func generateWorkloadNodePoolModel(ctx context.Context, workloadpools generated.KubernetesClusterWorkloadPools) []workloadNodePoolModel {
 	var workloadPools []workloadNodePoolModel
 	for _, pool := range workloadpools {
 		workloadPool := workloadNodePoolModel{
-			Name:     types.StringValue(pool.Name),
-			Disk:     types.Int64Value(int64(pool.Machine.Disk.Size)),
-			Flavor:   types.StringValue(pool.Machine.FlavorName),
-			Image:    types.StringValue(pool.Machine.ImageName),
-			Replicas: types.Int64Value(int64(pool.Machine.Replicas)),
-			Version:  types.StringValue(pool.Machine.Version),
+			Name:                   types.StringValue(pool.Name),
+			Disk:                   types.Int64Null(),
+			Flavor:                 types.StringValue(pool.Machine.FlavorName),
+			Image:                  types.StringValue(pool.Machine.ImageName),
+			ImageAutoSelect:        types.BoolValue(false),
+			Replicas:               types.Int64Value(int64(pool.Machine.Replicas)),
+			Version:                types.StringValue(pool.Machine.Version),
+			VolumeAvailabilityZone: types.StringNull(),
+		}
+		if pool.Machine.Disk != nil {
+			workloadPool.Disk = types.Int64Value(int64(pool.Machine.Disk.Size))
+			workloadPool.VolumeAvailabilityZone = types.StringPointerValue(pool.Machine.Disk.AvailabilityZone)
 		}
 		if pool.Autoscaling != nil {
 			workloadPool.Autoscaling = &autoscalingModel{
@@ -172,8 +635,17 @@ func generateWorkloadNodePoolModel(ctx context.Context, workloadpools generated.
 				MaximumReplicas: types.Int64Value(int64(pool.Autoscaling.MaximumReplicas)),
 			}
 		}
-		if pool.Labels != nil && len(*pool.Labels) != 0 {
-			workloadPool.Labels, _ = types.MapValueFrom(ctx, types.StringType, pool.Labels)
+		// The pool label is managed by the provider, not configured.
+		labels := map[string]string{}
+		if pool.Labels != nil {
+			for k, v := range *pool.Labels {
+				if k != poolLabel {
+					labels[k] = v
+				}
+			}
+		}
+		if len(labels) != 0 {
+			workloadPool.Labels, _ = types.MapValueFrom(ctx, types.StringType, labels)
 		} else {
 			workloadPool.Labels = types.MapNull(types.StringType)
 		}
@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/eschercloudai/eckctl/pkg/generated"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testPool returns a workload pool for tests.
+func testPool(name string, version string, labels map[string]string) generated.KubernetesClusterWorkloadPool {
+	pool := generated.KubernetesClusterWorkloadPool{
+		Name: name,
+		Machine: generated.OpenstackMachinePool{
+			FlavorName: "m1.large",
+			ImageName:  "eck-" + version,
+			Replicas:   1,
+			Version:    version,
+		},
+	}
+
+	if labels != nil {
+		pool.Labels = &labels
+	}
+
+	return pool
+}
+
+func TestLabelPools(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pool    generated.KubernetesClusterWorkloadPool
+		current *generated.KubernetesCluster
+		want    map[string]string
+	}{
+		{
+			name: "new cluster",
+			pool: testPool("cpu", "v1.28.3", map[string]string{"role": "cpu"}),
+			want: map[string]string{"role": "cpu", poolLabel: "cpu"},
+		},
+		{
+			name: "new pool",
+			pool: testPool("gpu", "v1.28.3", nil),
+			current: &generated.KubernetesCluster{
+				WorkloadPools: generated.KubernetesClusterWorkloadPools{testPool("cpu", "v1.28.3", nil)},
+			},
+			want: map[string]string{poolLabel: "gpu"},
+		},
+		{
+			name: "labelled pool",
+			pool: testPool("cpu", "v1.28.3", nil),
+			current: &generated.KubernetesCluster{
+				WorkloadPools: generated.KubernetesClusterWorkloadPools{testPool("cpu", "v1.28.3", map[string]string{poolLabel: "cpu"})},
+			},
+			want: map[string]string{poolLabel: "cpu"},
+		},
+		{
+			name: "unlabelled pool",
+			pool: testPool("cpu", "v1.28.3", map[string]string{"role": "cpu"}),
+			current: &generated.KubernetesCluster{
+				WorkloadPools: generated.KubernetesClusterWorkloadPools{testPool("cpu", "v1.28.3", map[string]string{"role": "cpu"})},
+			},
+			want: map[string]string{"role": "cpu"},
+		},
+		{
+			name: "unlabelled pool being upgraded",
+			pool: testPool("cpu", "v1.28.4", nil),
+			current: &generated.KubernetesCluster{
+				WorkloadPools: generated.KubernetesClusterWorkloadPools{testPool("cpu", "v1.28.3", nil)},
+			},
+			want: map[string]string{poolLabel: "cpu"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			cluster := generated.KubernetesCluster{
+				WorkloadPools: generated.KubernetesClusterWorkloadPools{test.pool},
+			}
+
+			labelPools(&cluster, test.current)
+
+			var got map[string]string
+			if labels := cluster.WorkloadPools[0].Labels; labels != nil {
+				got = *labels
+			}
+
+			if len(got) != len(test.want) {
+				t.Fatalf("labels = %v, want %v", got, test.want)
+			}
+
+			for k, v := range test.want {
+				if got[k] != v {
+					t.Errorf("labels = %v, want %v", got, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLabelPoolsCopiesLabels(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"role": "cpu"}
+	cluster := generated.KubernetesCluster{
+		WorkloadPools: generated.KubernetesClusterWorkloadPools{testPool("cpu", "v1.28.3", labels)},
+	}
+
+	labelPools(&cluster, nil)
+
+	if _, ok := labels[poolLabel]; ok {
+		t.Errorf("labelPools() modified the configured labels: %v", labels)
+	}
+}
+
+func TestPlanAutoscaledReplicas(t *testing.T) {
+	t.Parallel()
+
+	autoscaling := func(minimum, maximum int64) *autoscalingModel {
+		return &autoscalingModel{
+			MinimumReplicas: types.Int64Value(minimum),
+			MaximumReplicas: types.Int64Value(maximum),
+		}
+	}
+
+	pool := func(name string, replicas types.Int64, autoscaling *autoscalingModel) workloadNodePoolModel {
+		return workloadNodePoolModel{
+			Name:        types.StringValue(name),
+			Replicas:    replicas,
+			Autoscaling: autoscaling,
+		}
+	}
+
+	unknown := types.Int64Unknown()
+
+	tests := []struct {
+		name     string
+		plan     workloadNodePoolModel
+		state    []workloadNodePoolModel
+		want     types.Int64
+		changed  bool
+		warnings int
+	}{
+		{
+			name:    "new cluster",
+			plan:    pool("cpu", unknown, autoscaling(2, 5)),
+			want:    types.Int64Value(2),
+			changed: true,
+		},
+		{
+			name:    "new pool",
+			plan:    pool("gpu", unknown, autoscaling(1, 3)),
+			state:   []workloadNodePoolModel{pool("cpu", types.Int64Value(4), autoscaling(2, 5))},
+			want:    types.Int64Value(1),
+			changed: true,
+		},
+		{
+			name:    "existing pool",
+			plan:    pool("cpu", unknown, autoscaling(2, 5)),
+			state:   []workloadNodePoolModel{pool("cpu", types.Int64Value(4), autoscaling(2, 5))},
+			want:    types.Int64Value(4),
+			changed: true,
+		},
+		{
+			name:    "existing pool newly autoscaled",
+			plan:    pool("cpu", unknown, autoscaling(2, 5)),
+			state:   []workloadNodePoolModel{pool("cpu", types.Int64Value(4), nil)},
+			want:    types.Int64Value(2),
+			changed: true,
+		},
+		{
+			name:    "minimum raised",
+			plan:    pool("cpu", unknown, autoscaling(6, 8)),
+			state:   []workloadNodePoolModel{pool("cpu", types.Int64Value(4), autoscaling(2, 5))},
+			want:    types.Int64Value(6),
+			changed: true,
+		},
+		{
+			name:    "minimum lowered",
+			plan:    pool("cpu", unknown, autoscaling(1, 5)),
+			state:   []workloadNodePoolModel{pool("cpu", types.Int64Value(4), autoscaling(2, 5))},
+			want:    types.Int64Value(4),
+			changed: true,
+		},
+		{
+			name:    "maximum lowered",
+			plan:    pool("cpu", unknown, autoscaling(2, 3)),
+			state:   []workloadNodePoolModel{pool("cpu", types.Int64Value(4), autoscaling(2, 5))},
+			want:    types.Int64Value(3),
+			changed: true,
+		},
+		{
+			name: "unknown minimum",
+			plan: pool("cpu", unknown, &autoscalingModel{
+				MinimumReplicas: types.Int64Unknown(),
+				MaximumReplicas: types.Int64Value(5),
+			}),
+			state:   []workloadNodePoolModel{pool("cpu", types.Int64Value(4), autoscaling(2, 5))},
+			want:    types.Int64Value(4),
+			changed: true,
+		},
+		{
+			name:  "configured replicas",
+			plan:  pool("cpu", types.Int64Value(4), autoscaling(2, 5)),
+			state: []workloadNodePoolModel{pool("cpu", types.Int64Value(4), autoscaling(2, 5))},
+			want:  types.Int64Value(4),
+		},
+		{
+			name:     "configured replicas override autoscaler",
+			plan:     pool("cpu", types.Int64Value(2), autoscaling(2, 5)),
+			state:    []workloadNodePoolModel{pool("cpu", types.Int64Value(4), autoscaling(2, 5))},
+			want:     types.Int64Value(2),
+			warnings: 1,
+		},
+		{
+			name:  "not autoscaled",
+			plan:  pool("cpu", unknown, nil),
+			state: []workloadNodePoolModel{pool("cpu", types.Int64Value(4), autoscaling(2, 5))},
+			want:  unknown,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan := &clusterModel{WorkloadNodePools: []workloadNodePoolModel{test.plan}}
+
+			var state *clusterModel
+			if test.state != nil {
+				state = &clusterModel{WorkloadNodePools: test.state}
+			}
+
+			var diags diag.Diagnostics
+
+			if changed := planAutoscaledReplicas(plan, state, &diags); changed != test.changed {
+				t.Errorf("planAutoscaledReplicas() = %t, want %t", changed, test.changed)
+			}
+
+			if got := plan.WorkloadNodePools[0].Replicas; !got.Equal(test.want) {
+				t.Errorf("replicas = %s, want %s", got, test.want)
+			}
+
+			if diags.HasError() || diags.WarningsCount() != test.warnings {
+				t.Errorf("diagnostics = %v, want %d warnings", diags, test.warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/eschercloudai/eckctl/pkg/generated"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &clusterNodesDataSource{}
+	_ datasource.DataSourceWithConfigure = &clusterNodesDataSource{}
+)
+
+// NewClusterNodesDataSource is a helper function to simplify the provider implementation.
+func NewClusterNodesDataSource() datasource.DataSource {
+	return &clusterNodesDataSource{}
+}
+
+// clusterNodesDataSource is the data source implementation.
+type clusterNodesDataSource struct {
+	client *generated.ClientWithResponses
+}
+
+// clusterNodesModel maps the cluster nodes data source schema data.
+type clusterNodesModel struct {
+	EckCp types.String       `tfsdk:"eckcp"`
+	Name  types.String       `tfsdk:"name"`
+	Nodes []clusterNodeModel `tfsdk:"nodes"`
+	Pools []clusterPoolModel `tfsdk:"pools"`
+}
+
+// clusterNodeModel maps a node of the cluster.
+type clusterNodeModel struct {
+	Addresses         types.List   `tfsdk:"addresses"`
+	ControlPlane      types.Bool   `tfsdk:"control_plane"`
+	InternalIP        types.String `tfsdk:"internal_ip"`
+	KubernetesVersion types.String `tfsdk:"kubernetes_version"`
+	Name              types.String `tfsdk:"name"`
+	Phase             types.String `tfsdk:"phase"`
+	Pool              types.String `tfsdk:"pool"`
+}
+
+// clusterPoolModel maps the node counts of a workload pool.
+type clusterPoolModel struct {
+	Name          types.String `tfsdk:"name"`
+	Replicas      types.Int64  `tfsdk:"replicas"`
+	Nodes         types.Int64  `tfsdk:"nodes"`
+	ReadyReplicas types.Int64  `tfsdk:"ready_replicas"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *clusterNodesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T with value of %v. Please report this issue to the provider developers.", req.ProviderData, req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// Metadata returns the data source type name.
+func (d *clusterNodesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_nodes"
+}
+
+// Schema defines the schema for the data source.
+func (d *clusterNodesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the nodes of a provisioned cluster.  Nodes are read from the Kubernetes API of the cluster, so it must be reachable from where Terraform runs.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "The name of the ECK cluster.",
+				Required:    true,
+			},
+			"eckcp": schema.StringAttribute{
+				Description: "The associated ECK Control Plane for the cluster.",
+				Required:    true,
+			},
+			"nodes": schema.ListNestedAttribute{
+				Description: "The nodes of the cluster, ordered by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the node.",
+							Computed:    true,
+						},
+						"pool": schema.StringAttribute{
+							Description: "The workload pool the node belongs to.  Null for control plane nodes, and for nodes of pools whose machines have not been replaced since they were created by a version of the provider which did not label nodes with their pool.",
+							Computed:    true,
+						},
+						"control_plane": schema.BoolAttribute{
+							Description: "Whether the node is part of the control plane.",
+							Computed:    true,
+						},
+						"internal_ip": schema.StringAttribute{
+							Description: "The internal IP address of the node.",
+							Computed:    true,
+						},
+						"addresses": schema.ListAttribute{
+							Description: "All internal and external IP addresses of the node.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"phase": schema.StringAttribute{
+							Description: "The readiness of the node, one of `Ready`, `NotReady` or `Unknown`.",
+							Computed:    true,
+						},
+						"kubernetes_version": schema.StringAttribute{
+							Description: "The version of Kubernetes the node's kubelet is running.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"pools": schema.ListNestedAttribute{
+				Description: "The workload pools of the cluster, with how many of their nodes have joined the cluster and are ready, in the order of `eck_cluster.workloadnodepools`.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the workload pool.",
+							Computed:    true,
+						},
+						"replicas": schema.Int64Attribute{
+							Description: "The number of machines requested for the pool.",
+							Computed:    true,
+						},
+						"nodes": schema.Int64Attribute{
+							Description: "The number of nodes of the pool which have joined the cluster.  Null if the nodes of the pool are not labelled with their pool, see `nodes.pool`.",
+							Computed:    true,
+						},
+						"ready_replicas": schema.Int64Attribute{
+							Description: "The number of nodes of the pool which are `Ready`.  Null if the nodes of the pool are not labelled with their pool, see `nodes.pool`.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *clusterNodesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state clusterNodesModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cluster, err := getCluster(ctx, d.client, state.EckCp.ValueString(), state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to retrieve cluster information",
+			"Could not read cluster "+state.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	kubeconfig, err := getKubeconfig(ctx, d.client, state.EckCp.ValueString(), cluster.Name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to retrieve kubeconfig",
+			"Could not read kubeconfig for cluster "+cluster.Name+": "+err.Error(),
+		)
+		return
+	}
+
+	client, err := newKubernetesClient(kubeconfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to retrieve cluster nodes",
+			"Could not use kubeconfig for cluster "+cluster.Name+": "+err.Error(),
+		)
+		return
+	}
+
+	nodes, err := client.listNodes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to retrieve cluster nodes",
+			"Could not list nodes of cluster "+cluster.Name+": "+err.Error(),
+		)
+		return
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Metadata.Name < nodes[j].Metadata.Name
+	})
+
+	state.Nodes = make([]clusterNodeModel, 0, len(nodes))
+
+	for _, n := range nodes {
+		state.Nodes = append(state.Nodes, generateClusterNodeModel(ctx, n))
+	}
+
+	state.Pools = generateClusterPoolModels(cluster.WorkloadPools, state.Nodes)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// generateClusterNodeModel maps a Kubernetes node to the data source model.
+func generateClusterNodeModel(ctx context.Context, n node) clusterNodeModel {
+	model := clusterNodeModel{
+		ControlPlane:      types.BoolValue(n.controlPlane()),
+		InternalIP:        types.StringNull(),
+		KubernetesVersion: stringValueOrNull(n.Status.NodeInfo.KubeletVersion),
+		Name:              types.StringValue(n.Metadata.Name),
+		Phase:             types.StringValue(n.phase()),
+		Pool:              types.StringNull(),
+	}
+
+	addresses := []string{}
+
+	for _, address := range n.Status.Addresses {
+		switch address.Type {
+		case "InternalIP":
+			if model.InternalIP.IsNull() {
+				model.InternalIP = types.StringValue(address.Address)
+			}
+
+			addresses = append(addresses, address.Address)
+		case "ExternalIP":
+			addresses = append(addresses, address.Address)
+		}
+	}
+
+	model.Addresses = stringSliceToTfList(ctx, &addresses)
+
+	if !n.controlPlane() {
+		model.Pool = nodePool(n)
+	}
+
+	return model
+}
+
+// nodePool returns the workload pool a node belongs to, from the label the
+// provider sets on the nodes of each pool.
+func nodePool(n node) types.String {
+	pool, ok := n.Metadata.Labels[poolLabel]
+	if !ok || pool == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(pool)
+}
+
+// generateClusterPoolModels counts the nodes, and ready nodes, of each
+// workload pool.  Pools whose nodes are not labelled with their pool cannot be
+// counted, so their counts are null.
+func generateClusterPoolModels(pools generated.KubernetesClusterWorkloadPools, nodes []clusterNodeModel) []clusterPoolModel {
+	models := make([]clusterPoolModel, 0, len(pools))
+
+	for _, pool := range pools {
+		model := clusterPoolModel{
+			Name:          types.StringValue(pool.Name),
+			Replicas:      types.Int64Value(int64(pool.Machine.Replicas)),
+			Nodes:         types.Int64Null(),
+			ReadyReplicas: types.Int64Null(),
+		}
+
+		if poolLabelled(pool) {
+			var count, ready int64
+
+			for _, n := range nodes {
+				if n.Pool.ValueString() != pool.Name {
+					continue
+				}
+
+				count++
+
+				if n.Phase.ValueString() == "Ready" {
+					ready++
+				}
+			}
+
+			model.Nodes = types.Int64Value(count)
+			model.ReadyReplicas = types.Int64Value(ready)
+		}
+
+		models = append(models, model)
+	}
+
+	return models
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/eschercloudai/eckctl/pkg/generated"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNodePool(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		node   string
+		labels map[string]string
+		want   types.String
+	}{
+		{
+			name:   "labelled",
+			node:   "cluster-a8d33e78-pool-68ab84f7-b5652fd1-42tst",
+			labels: map[string]string{poolLabel: "cpu"},
+			want:   types.StringValue("cpu"),
+		},
+		{
+			name:   "pool named like part of the node name",
+			node:   "cluster-a8d33e78-pool-68ab84f7-b5652fd1-42tst",
+			labels: map[string]string{poolLabel: "gpu"},
+			want:   types.StringValue("gpu"),
+		},
+		{
+			name: "unlabelled",
+			node: "cluster-a8d33e78-pool-68ab84f7-b5652fd1-42tst",
+			want: types.StringNull(),
+		},
+		{
+			name:   "other labels",
+			node:   "cluster-a8d33e78-pool-68ab84f7-b5652fd1-42tst",
+			labels: map[string]string{"gpu": "true"},
+			want:   types.StringNull(),
+		},
+		{
+			name:   "empty label",
+			node:   "cluster-a8d33e78-pool-68ab84f7-b5652fd1-42tst",
+			labels: map[string]string{poolLabel: ""},
+			want:   types.StringNull(),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var n node
+			n.Metadata.Name = test.node
+			n.Metadata.Labels = test.labels
+
+			if got := nodePool(n); !got.Equal(test.want) {
+				t.Errorf("nodePool() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGenerateClusterPoolModels(t *testing.T) {
+	t.Parallel()
+
+	pools := generated.KubernetesClusterWorkloadPools{
+		testPool("cpu", "v1.28.3", map[string]string{poolLabel: "cpu"}),
+		testPool("gpu", "v1.28.3", map[string]string{poolLabel: "gpu"}),
+		testPool("old", "v1.28.3", nil),
+	}
+	pools[0].Machine.Replicas = 3
+
+	nodes := []clusterNodeModel{
+		{Name: types.StringValue("cluster-a8d33e78-control-plane-5e671f5e-cl6gd"), Pool: types.StringNull(), Phase: types.StringValue("Ready")},
+		{Name: types.StringValue("cluster-a8d33e78-pool-68ab84f7-b5652fd1-42tst"), Pool: types.StringValue("cpu"), Phase: types.StringValue("Ready")},
+		{Name: types.StringValue("cluster-a8d33e78-pool-68ab84f7-b5652fd1-9xk2p"), Pool: types.StringValue("cpu"), Phase: types.StringValue("NotReady")},
+		{Name: types.StringValue("cluster-a8d33e78-pool-0c1d9e44-7f3a2b10-q8zt4"), Pool: types.StringNull(), Phase: types.StringValue("Ready")},
+	}
+
+	want := []clusterPoolModel{
+		{Name: types.StringValue("cpu"), Replicas: types.Int64Value(3), Nodes: types.Int64Value(2), ReadyReplicas: types.Int64Value(1)},
+		{Name: types.StringValue("gpu"), Replicas: types.Int64Value(1), Nodes: types.Int64Value(0), ReadyReplicas: types.Int64Value(0)},
+		{Name: types.StringValue("old"), Replicas: types.Int64Value(1), Nodes: types.Int64Null(), ReadyReplicas: types.Int64Null()},
+	}
+
+	got := generateClusterPoolModels(pools, nodes)
+	if len(got) != len(want) {
+		t.Fatalf("generateClusterPoolModels() returned %d pools, want %d", len(got), len(want))
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("pool %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
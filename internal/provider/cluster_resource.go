@@ -2,21 +2,25 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"regexp"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/eschercloudai/eckctl/pkg/generated"
@@ -27,8 +31,12 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &clusterResource{}
-	_ resource.ResourceWithConfigure = &clusterResource{}
+	_ resource.Resource                     = &clusterResource{}
+	_ resource.ResourceWithConfigure        = &clusterResource{}
+	_ resource.ResourceWithValidateConfig   = &clusterResource{}
+	_ resource.ResourceWithConfigValidators = &clusterResource{}
+	_ resource.ResourceWithModifyPlan       = &clusterResource{}
+	_ resource.ResourceWithImportState      = &clusterResource{}
 )
 
 // NewClusterResource is a helper function to simplify the provider implementation.
@@ -39,6 +47,7 @@ func NewClusterResource() resource.Resource {
 // clusterResource is the resource implementation.
 type clusterResource struct {
 	client *generated.ClientWithResponses
+	poll   pollConfig
 }
 
 // Configure adds the provider configured client to the resource.
@@ -47,18 +56,19 @@ func (r *clusterResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*generated.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *generated.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.poll = data.poll
 }
 
 // Metadata returns the resource type name.
@@ -70,24 +80,78 @@ func (r *clusterResource) Metadata(_ context.Context, req resource.MetadataReque
 func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the cluster, in the form `eckcp/name`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"name": schema.StringAttribute{
-				Description: "The name of the ECK cluster.",
+				Description: "The name of the ECK cluster.  Must be a valid DNS label.",
 				Required:    true,
+				Validators:  dnsLabelValidators(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"eckcp": schema.StringAttribute{
 				Description: "The associated ECK Control Plane for the cluster.",
 				Default:     stringdefault.StaticString("default"),
 				Computed:    true,
 				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"applicationbundle": schema.StringAttribute{
-				Description: "The version of the bundled components in the cluster.  See https://docs.eschercloud.ai/Kubernetes/Reference/compatibility_matrix for details.",
-				Computed:    true,
+				Description: "The version of the bundled components in the cluster.  See https://docs.eschercloud.ai/Kubernetes/Reference/compatibility_matrix for details.  " +
+					"Defaults to the newest bundle which is neither in preview nor end of life when the cluster is created.",
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"autoupgrade": schema.SingleNestedAttribute{
+				Description: "Automatic upgrades of the cluster's application bundle.  Clusters are always upgraded once their bundle reaches end of life, regardless of this setting.",
 				Optional:    true,
-				Default:     stringdefault.StaticString("kubernetes-cluster-1.4.1"),
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						Description: "Whether to upgrade the application bundle automatically when a newer, non-preview version is available.",
+						Required:    true,
+					},
+					"days_of_week": daysOfWeekAttribute(),
+				},
 			},
 			"kubeconfig": schema.StringAttribute{
-				Description: "The kubeconfig for the cluster.",
+				Description: "The kubeconfig for the cluster.  Fetched once the cluster is provisioned, and then kept until `kubeconfig_rotation` changes.  Null if `store_kubeconfig` is false.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"store_kubeconfig": schema.BoolAttribute{
+				Description: "Whether to store the kubeconfig in state.  If false, `kubeconfig` is null, and the kubeconfig is only fetched to find `api_endpoint` and to wait for nodes, " +
+					"so cluster admin credentials are kept out of state.  Use the `eck_kubeconfig` data source to read the kubeconfig instead.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"kubeconfig_rotation": schema.StringAttribute{
+				Description: "An arbitrary value which, when changed, causes the kubeconfig to be fetched again, e.g. after the cluster credentials were rotated.  " +
+					"If `wait` is false, the new kubeconfig is fetched on the next refresh.",
+				Optional: true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "The time the cluster was created, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"api_endpoint": schema.StringAttribute{
+				Description: "The URL of the Kubernetes API of the cluster, taken from the kubeconfig.  Null until the cluster is provisioned and the kubeconfig has been read.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -97,34 +161,104 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "The provisioning status of the cluster.",
 				Computed:    true,
 			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether to prevent the cluster from being destroyed.  Must be set to false, and applied, before the cluster can be deleted.",
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 			"wait": schema.BoolAttribute{
-				Description: "Whether to wait for the cluster to be provisioned",
+				Description: "Whether to wait for the cluster to be provisioned.  If false, `kubeconfig` is empty after the cluster is created until it is refreshed once provisioned.",
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"wait_interval": schema.StringAttribute{
+				Description: "How often to poll the cluster status while waiting for it to be provisioned, e.g. `30s`.  " +
+					"If not configured, polling backs off between the provider's `poll_interval_min` and `poll_interval_max` instead of using a fixed interval.",
+				Optional: true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
+			"wait_for_nodes": schema.BoolAttribute{
+				Description: "Whether to also wait, after the cluster is provisioned, until the expected number of nodes are `Ready` in Kubernetes.  Autoscaled pools are expected to reach their `minimum`.  Requires `wait`, and network access to the Kubernetes API of the cluster.",
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"rolling_upgrade": schema.BoolAttribute{
+				Description: "Whether to upgrade the Kubernetes version or images of the cluster in stages: the control plane first, then each workload pool in turn, waiting for the cluster to be provisioned after each stage.  Other changes to workload pools are applied once all pools are upgraded.  Requires `wait`.",
 				Computed:    true,
 				Optional:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"wait_timeout": schema.StringAttribute{
+				Description: "How long to wait for the cluster to be provisioned before giving up, e.g. `10m`. Defaults to `10m`.",
+				Computed:    true,
+				Optional:    true,
+				Default:     stringdefault.StaticString("10m"),
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
+			"api": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Options for the Kubernetes API endpoint of the cluster.",
+				Attributes: map[string]schema.Attribute{
+					"allowed_prefixes": schema.ListAttribute{
+						Description: "CIDR-formatted IP address ranges allowed to access the Kubernetes API.  If unset, access is unrestricted.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(validCIDR()),
+						},
+					},
+					"subject_alternative_names": schema.ListAttribute{
+						Description: "Additional names added to the Kubernetes API server certificate, e.g. when the API is exposed through a DNS alias.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+				},
+			},
 			"controlplane": schema.SingleNestedAttribute{
 				Required: true,
 				Attributes: map[string]schema.Attribute{
 					"disk": schema.Int64Attribute{
-						Description: "Whether to use a dedicated persistent volume for control plane nodes. It is recommended to leave this unchecked, as ephemeral storage provides higher performance for Kubernetes' etcd database. If left unset, the default ephemeral storage size of 20GB is used.",
+						Description: "Size in GiB of a dedicated persistent volume for control plane nodes. It is recommended to leave this unset, as ephemeral storage provides higher performance for Kubernetes' etcd database. If left unset, the default ephemeral storage size of 20GB is used.  Must be between 10 and 2000 GiB.",
 						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(minDiskSize, maxDiskSize),
+						},
 					},
 					"flavor": schema.StringAttribute{
-						Description: "The flavor (size) of the machine.",
+						Description: "The flavor (size) of the machine.  Must have at least 2 vCPUs and 4 GiB of memory.",
 						Required:    true,
 					},
 					"image": schema.StringAttribute{
-						Description: "Which OS image to use.  Must be a verified and signed ECK image",
-						Required:    true,
+						Description: "Which OS image to use.  Must be a verified and signed ECK image.  Required unless `image_auto_select` is set.",
+						Optional:    true,
+						Computed:    true,
+					},
+					"image_auto_select": schema.BoolAttribute{
+						Description: "Whether to select the newest image for `version` instead of setting `image`.  The image is selected when the cluster is created and whenever `version` changes.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(false),
 					},
 					"replicas": schema.Int64Attribute{
 						Description: "How many replicas to provision in a control plane.  Must be an odd number, 3 is recommended.",
 						Required:    true,
+						Validators: []validator.Int64{
+							oddReplicas(),
+						},
 					},
 					"version": schema.StringAttribute{
-						Description: "The version of Kubernetes.  Must match the version bundled with the OS image.",
+						Description: "The version of Kubernetes, e.g. `v1.28.3`.  Must match the version bundled with the OS image.",
 						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(kubernetesVersionPattern, "Must be a Kubernetes version such as v1.28.3"),
+						},
 					},
 				},
 			},
@@ -132,44 +266,51 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Required: true,
 				Attributes: map[string]schema.Attribute{
 					"dnsnameservers": schema.ListAttribute{
-						Description: "A list of DNS nameservers used by the OS.",
+						Description: "A list of IPv4 or IPv6 DNS nameservers used by the OS.  Defaults to the nameservers chosen by the platform.",
 						ElementType: types.StringType,
 						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.List{
+							listplanmodifier.UseStateForUnknown(),
+						},
 						Validators: []validator.List{
-							listvalidator.ValueStringsAre(stringvalidator.RegexMatches(
-								regexp.MustCompile(`^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9]?[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9]?[0-9])$`),
-								"Must be a valid IP address",
-							)),
+							listvalidator.ValueStringsAre(validIPAddress()),
 						},
 					},
 					"nodeprefix": schema.StringAttribute{
-						Description: "The CIDR-formatted IP address range to be used by Nodes in the cluster.",
+						Description: "The CIDR-formatted IPv4 or IPv6 address range to be used by Nodes in the cluster.  Defaults to the range chosen by the platform.",
 						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+							stringplanmodifier.RequiresReplace(),
+						},
 						Validators: []validator.String{
-							stringvalidator.RegexMatches(
-								regexp.MustCompile(`^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9]?[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9]?[0-9])\/(?:3[0-2]|[1-2]?[0-9])$`),
-								"Must be a valid CIDR-formatted range",
-							),
+							validCIDR(),
 						},
 					},
 					"podprefix": schema.StringAttribute{
-						Description: "The CIDR-formatted IP address range to be used by Pods in the cluster.",
+						Description: "The CIDR-formatted IPv4 or IPv6 address range to be used by Pods in the cluster.  Defaults to the range chosen by the platform.",
 						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+							stringplanmodifier.RequiresReplace(),
+						},
 						Validators: []validator.String{
-							stringvalidator.RegexMatches(
-								regexp.MustCompile(`^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9]?[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9]?[0-9])\/(?:3[0-2]|[1-2]?[0-9])$`),
-								"Must be a valid CIDR-formatted range",
-							),
+							validCIDR(),
 						},
 					},
 					"serviceprefix": schema.StringAttribute{
-						Description: "The CIDR-formatted IP address range to be used by Services in the cluster.",
+						Description: "The CIDR-formatted IPv4 or IPv6 address range to be used by Services in the cluster.  Defaults to the range chosen by the platform.",
 						Optional:    true,
+						Computed:    true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+							stringplanmodifier.RequiresReplace(),
+						},
 						Validators: []validator.String{
-							stringvalidator.RegexMatches(
-								regexp.MustCompile(`^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9]?[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9]?[0-9])\/(?:3[0-2]|[1-2]?[0-9])$`),
-								"Must be a valid CIDR-formatted range",
-							),
+							validCIDR(),
 						},
 					},
 				},
@@ -180,21 +321,21 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					"computeaz": schema.StringAttribute{
 						Optional:    true,
 						Computed:    true,
-						Default:     stringdefault.StaticString("nova"),
+						Default:     stringdefault.StaticString(defaultAvailabilityZone),
 						Description: "OpenStack Compute Availability Zone. Defaults to `nova`.",
 					},
 					"externalnetworkid": schema.StringAttribute{
-						Description: "UUID of the external network.",
+						Description: "UUID of the external network.  Omit to create a private cluster whose Kubernetes API is only reachable from the internal network.",
 						Optional:    true,
 					},
 					"sshkey": schema.StringAttribute{
-						Description: "SSH key associated with the instance.",
+						Description: "Name of the OpenStack SSH key pair installed on all control plane and workload pool machines.",
 						Optional:    true,
 					},
 					"volumeaz": schema.StringAttribute{
 						Optional:    true,
 						Computed:    true,
-						Default:     stringdefault.StaticString("nova"),
+						Default:     stringdefault.StaticString(defaultAvailabilityZone),
 						Description: "OpenStack Cinder Availability Zone. Defaults to `nova`.",
 					},
 				},
@@ -215,6 +356,12 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						Default:     booldefault.StaticBool(false),
 						Description: "Whether to deploy an Ingress Controller (NGINX).",
 					},
+					"certmanager": schema.BoolAttribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(false),
+						Description: "Whether to deploy cert-manager, e.g. to issue TLS certificates for ingresses.",
+					},
 					"longhorn": schema.BoolAttribute{
 						Optional:    true,
 						Computed:    true,
@@ -235,51 +382,100 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					},
 				},
 			},
+			"extra_features": schema.MapAttribute{
+				Description: "Additional feature flags passed to the ECK API as-is, keyed by their API name, e.g. `nvidiaOperator`.  " +
+					"Allows features added to the API to be used before the provider supports them.  Features with an attribute under `clusterfeatures` must be set there.  " +
+					"Values are not read back from the API, so changes made outside of Terraform are not detected.",
+				ElementType: types.BoolType,
+				Optional:    true,
+			},
+			"spec_json": schema.StringAttribute{
+				Description: "A JSON object deep merged over the cluster specification sent to the ECK API on create and update, e.g. `jsonencode({ network = { newField = true } })`.  " +
+					"Allows fields added to the API to be set before the provider supports them.  Objects are merged key by key, other values replace the generated value, and `null` removes it.  " +
+					"Values are not read back from the API, so changes made outside of Terraform are not detected.",
+				Optional: true,
+				Validators: []validator.String{
+					validJSONObject(),
+				},
+			},
 			"workloadnodepools": schema.ListNestedAttribute{
 				Optional: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"name": schema.StringAttribute{
-							Description: "Name of the workload pool.",
+							Description: "Name of the workload pool.  Must be a valid DNS label.",
 							Required:    true,
+							Validators:  dnsLabelValidators(),
 						},
 						"disk": schema.Int64Attribute{
 							Computed:    true,
 							Optional:    true,
-							Description: "Size of disk for the node.  Defaults to 50GiB.",
+							Description: "Size of disk for the node.  Must be between 10 and 2000 GiB.  Defaults to 50GiB.",
 							Default:     int64default.StaticInt64(50),
+							Validators: []validator.Int64{
+								int64validator.Between(minDiskSize, maxDiskSize),
+							},
 						},
 						"flavor": schema.StringAttribute{
 							Description: "OpenStack flavor (size) for nodes in this pool.",
 							Required:    true,
 						},
 						"image": schema.StringAttribute{
-							Description: "Operating system image to use.  Must be a valid and signed ECK image.",
-							Required:    true,
+							Description: "Operating system image to use.  Must be a valid and signed ECK image.  Required unless `image_auto_select` is set.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"image_auto_select": schema.BoolAttribute{
+							Description: "Whether to select the newest image for `version` instead of setting `image`.  The image is selected when the pool is created and whenever `version` changes.",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
 						},
 						"labels": schema.MapAttribute{
 							ElementType: types.StringType,
 							Optional:    true,
-							Description: "A map of Kubernetes labels to be applied to each node in the pool.",
+							Description: "A map of Kubernetes labels to be applied to each node in the pool.  The provider also labels nodes with `" + poolLabel + "` set to the name of their pool.",
+							Validators: []validator.Map{
+								mapvalidator.KeysAre(stringvalidator.NoneOf(poolLabel)),
+							},
 						},
 						"replicas": schema.Int64Attribute{
-							Description: "How many replicas in this workload pool.",
-							Required:    true,
+							Description: "How many replicas in this workload pool.  Required unless `autoscaling` is set, in which case it defaults to `autoscaling.minimum` and then follows the replica count chosen by the autoscaler, limited to the autoscaling range, rather than being scaled back on every apply.",
+							Optional:    true,
+							Computed:    true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
 						},
 						"version": schema.StringAttribute{
-							Optional: true,
+							Description: "The version of Kubernetes, e.g. `v1.28.3`.  Must match the version bundled with the OS image.  Defaults to `controlplane.version`.",
+							Optional:    true,
+							Computed:    true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(kubernetesVersionPattern, "Must be a Kubernetes version such as v1.28.3"),
+							},
+						},
+						"volumeaz": schema.StringAttribute{
+							Description: "OpenStack Cinder Availability Zone for the node disks in this pool.",
+							Optional:    true,
 						},
 						"autoscaling": schema.SingleNestedAttribute{
 							Description: "Configuration options for the autoscaler.",
 							Optional:    true,
 							Attributes: map[string]schema.Attribute{
 								"minimum": schema.Int64Attribute{
-									Description: "Minimum number of nodes in this pool.",
+									Description: "Minimum number of nodes in this pool.  Must not exceed `maximum`.",
 									Required:    true,
+									Validators: []validator.Int64{
+										int64validator.AtLeast(0),
+									},
 								},
 								"maximum": schema.Int64Attribute{
 									Description: "Maximum number of nodes in this pool.",
 									Required:    true,
+									Validators: []validator.Int64{
+										int64validator.AtLeast(0),
+									},
 								},
 							},
 						},
@@ -290,64 +486,436 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 	}
 }
 
-func waitForResourceToBeReady(ctx context.Context, client *generated.ClientWithResponses, cp string, cn string) error {
-	timeout := time.After(10 * time.Minute)
-	ticker := time.NewTicker(30 * time.Second)
-	defer ticker.Stop()
+// ConfigValidators returns the validators applied to the whole cluster
+// configuration.
+func (r *clusterResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		clusterNetworkOverlapValidator{},
+		clusterAutoscalingValidator{},
+		clusterAutoscalingRangeValidator{},
+		clusterPoolNamesValidator{},
+	}
+}
 
-	var cluster generated.KubernetesCluster
+// ValidateConfig checks for combinations of attributes which the ECK API would
+// accept but which produce a broken cluster.
+func (r *clusterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// The configuration is validated again once its values are known, before
+	// it is applied.
+	if hasUnknownObjects(req.Config.Raw) {
+		return
+	}
+
+	var config clusterModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Private clusters have no external network to allocate the ingress
+	// controller's load balancer address from.
+	private := config.ClusterOpenstack == nil || config.ClusterOpenstack.ExternalNetworkID.IsNull()
+	if private && config.ClusterFeatures != nil && config.ClusterFeatures.Ingress.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("clusterfeatures").AtName("ingress"),
+			"Ingress Requires an External Network",
+			"The ingress controller is exposed through a load balancer on the external network, but no "+
+				"clusteropenstack.externalnetworkid was provided.  Either set the external network or disable ingress.",
+		)
+	}
+
+	// A lone control plane node is fine for development, but should never
+	// slip into production unnoticed.
+	if config.ControlPlane != nil && !config.ControlPlane.Replicas.IsUnknown() && config.ControlPlane.Replicas.ValueInt64() == 1 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("controlplane").AtName("replicas"),
+			"Control Plane Is Not Highly Available",
+			"The control plane has a single replica, so the Kubernetes API and etcd are unavailable whenever that node is "+
+				"lost or replaced, and etcd data may be lost.  Use 3 replicas for production clusters.",
+		)
+	}
+
+	if config.WaitForNodes.ValueBool() && !config.Wait.IsUnknown() && !config.Wait.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("wait_for_nodes"),
+			"Waiting for Nodes Requires Wait",
+			"Nodes can only be checked once the cluster is provisioned.  Set wait = true to use wait_for_nodes.",
+		)
+	}
+
+	if config.RollingUpgrade.ValueBool() && !config.Wait.IsUnknown() && !config.Wait.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rolling_upgrade"),
+			"Rolling Upgrade Requires Wait",
+			"Each stage of a rolling upgrade must be provisioned before the next begins.  Set wait = true to use rolling_upgrade.",
+		)
+	}
+
+	for name := range config.ExtraFeatures.Elements() {
+		if attribute, ok := managedFeatures[name]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("extra_features").AtMapKey(name),
+				"Managed Cluster Feature",
+				fmt.Sprintf("The %q feature is managed by clusterfeatures.%s, which must be used instead.", name, attribute),
+			)
+		}
+	}
+
+	type imageConfig struct {
+		path       path.Path
+		image      types.String
+		autoSelect types.Bool
+	}
+
+	var images []imageConfig
+	if config.ControlPlane != nil {
+		images = append(images, imageConfig{path.Root("controlplane"), config.ControlPlane.Image, config.ControlPlane.ImageAutoSelect})
+	}
+	for i, pool := range config.WorkloadNodePools {
+		images = append(images, imageConfig{path.Root("workloadnodepools").AtListIndex(i), pool.Image, pool.ImageAutoSelect})
+	}
+
+	for _, image := range images {
+		if image.autoSelect.IsUnknown() || image.image.IsUnknown() {
+			continue
+		}
+
+		if image.autoSelect.ValueBool() && !image.image.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				image.path.AtName("image"),
+				"Conflicting Image Selection",
+				"An image cannot be set when image_auto_select is true.  Remove the image, or set image_auto_select to false.",
+			)
+		}
+
+		if !image.autoSelect.ValueBool() && image.image.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				image.path.AtName("image"),
+				"Missing Image",
+				"An image must be set unless image_auto_select is true.",
+			)
+		}
+	}
+
+	for i, pool := range config.WorkloadNodePools {
+		if pool.Autoscaling == nil && pool.Replicas.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("workloadnodepools").AtListIndex(i).AtName("replicas"),
+				"Missing Workload Pool Replicas",
+				fmt.Sprintf("Workload pool %q must set replicas, as it does not set autoscaling.", pool.Name.ValueString()),
+			)
+		}
+	}
+}
+
+// ModifyPlan rejects changes which the ECK API would accept but which would
+// break an existing cluster.
+func (r *clusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state *clusterModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The framework only reports replacements from attribute plan modifiers
+	// after ModifyPlan, so compare the attributes which require replacement.
+	if state != nil && state.DeletionProtection.ValueBool() {
+		if replaced := replacedAttributes(ctx, req, &resp.Diagnostics); len(replaced) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				replaced[0],
+				"Cluster Is Protected From Deletion",
+				"Cluster "+state.Name.ValueString()+" has deletion_protection enabled, and this change requires it to be replaced.  "+
+					"Set deletion_protection to false and apply the change before replacing the cluster.",
+			)
+			return
+		}
+	}
+
+	// The plan cannot be read into the model while nested objects are
+	// unknown.  It is modified again once they are known, before it is
+	// applied.
+	if hasUnknownObjects(req.Plan.Raw) {
+		return
+	}
+
+	var plan clusterModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changed := planPoolVersions(&plan)
+	if planAutoscaledReplicas(&plan, state, &resp.Diagnostics) {
+		changed = true
+	}
+
+	if changed {
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The client is not configured when the provider configuration depends on
+	// values which are not yet known.
+	if r.client != nil {
+		catalog := newClusterCatalog(r.client)
+
+		resp.Diagnostics.Append(selectImages(ctx, catalog, &plan, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+
+		if plan.ApplicationBundle.IsUnknown() {
+			defaultApplicationBundle(ctx, catalog, &plan.ApplicationBundle, &resp.Diagnostics)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("applicationbundle"), plan.ApplicationBundle)...)
+		}
+
+		checkReferences(ctx, catalog, plan, state, resp)
+		resp.Diagnostics.Append(checkControlPlaneFlavor(ctx, catalog, plan, state)...)
+		resp.Diagnostics.Append(checkImageVersions(ctx, catalog, plan, state)...)
+
+		// Bundles already in use were warned about when they were planned.
+		if !plan.ApplicationBundle.IsUnknown() && (state == nil || !plan.ApplicationBundle.Equal(state.ApplicationBundle)) {
+			resp.Diagnostics.Append(checkClusterBundle(ctx, catalog, plan.ApplicationBundle.ValueString())...)
+		}
+	}
+
+	if !plan.StoreKubeconfig.IsUnknown() && !plan.StoreKubeconfig.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("kubeconfig"), types.StringNull())...)
+	}
+
+	// Nothing to compare against on create.
+	if state == nil {
+		// Without waiting the cluster has no kubeconfig yet, so providers
+		// configured from it in the same run fail with confusing errors.
+		if !plan.Wait.IsUnknown() && !plan.Wait.ValueBool() && plan.StoreKubeconfig.ValueBool() {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("kubeconfig"),
+				"Kubeconfig Will Be Empty",
+				"The cluster is not waited for, so kubeconfig is empty and api_endpoint is null until a refresh after the cluster is provisioned.  "+
+					"Providers such as kubernetes and helm configured from them will fail in this run.  "+
+					"Set wait = true, or read the kubeconfig with the eck_kubeconfig data source with wait = true.",
+			)
+		}
+
+		return
+	}
+
+	if plan.StoreKubeconfig.ValueBool() {
+		r.modifyKubeconfigPlan(ctx, req, resp)
+	}
+
+	versionPath := path.Root("controlplane").AtName("version")
+
+	var planned, current types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, versionPath, &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, versionPath, &current)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planned.IsUnknown() || planned.IsNull() || current.IsNull() {
+		return
+	}
+
+	// Versions which fail to parse are reported by the schema validators.
+	if cmp, err := compareKubernetesVersions(planned.ValueString(), current.ValueString()); err == nil && cmp < 0 {
+		resp.Diagnostics.AddAttributeError(
+			versionPath,
+			"Kubernetes Version Downgrade",
+			fmt.Sprintf("The control plane version cannot be lowered from %s to %s, as Kubernetes does not support downgrades. "+
+				"To run an older version, create a new cluster.", current.ValueString(), planned.ValueString()),
+		)
+	}
+}
+
+// defaultApplicationBundle sets an unconfigured application bundle to the
+// newest supported bundle.
+func defaultApplicationBundle(ctx context.Context, catalog *clusterCatalog, bundle *types.String, diags *diag.Diagnostics) {
+	name, err := latestClusterBundle(ctx, catalog)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("applicationbundle"),
+			"Error Selecting Application Bundle",
+			"Could not select the default application bundle, set applicationbundle explicitly: "+err.Error(),
+		)
+		return
+	}
+
+	*bundle = types.StringValue(name)
+}
+
+// modifyKubeconfigPlan marks the kubeconfig as changing when an update will
+// fetch it again, either because rotation was requested or because no valid
+// kubeconfig is stored.
+func (r *clusterResource) modifyKubeconfigPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var planned, current, kubeconfig types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("kubeconfig_rotation"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("kubeconfig_rotation"), &current)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("kubeconfig"), &kubeconfig)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without other changes there is no update to fetch the kubeconfig, so it
+	// is left to the next refresh.
+	if planned.Equal(current) && (validKubeconfig(kubeconfig) || req.Plan.Raw.Equal(req.State.Raw)) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("kubeconfig"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("api_endpoint"), types.StringUnknown())...)
+}
+
+// waitForResourceToBeReady polls a cluster until it is provisioned.  The last
+// cluster read from the API is returned, even on error, so callers can record
+// its status without fetching it again.
+func waitForResourceToBeReady(ctx context.Context, client *generated.ClientWithResponses, cp string, cn string, poll pollConfig, timeout time.Duration) (*generated.KubernetesCluster, error) {
+	deadline := time.After(timeout)
+	schedule := poll.schedule()
+
+	var last *generated.KubernetesCluster
 
 	for {
+		timer := time.NewTimer(schedule.Next())
+
 		select {
 		case <-ctx.Done():
-			return fmt.Errorf("operation was canceled")
-		case <-timeout:
-			return fmt.Errorf("timed out waiting for resource to be ready")
-		case <-ticker.C:
-			resp, err := client.GetApiV1ControlplanesControlPlaneNameClustersClusterName(ctx, cp, cn)
-			if err != nil {
-				return err
-			}
-			if resp.StatusCode != http.StatusOK {
-				return fmt.Errorf("%v", resp.StatusCode)
-			}
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return err
-			}
-			err = json.Unmarshal(body, &cluster)
+			timer.Stop()
+			return last, fmt.Errorf("operation was canceled")
+		case <-deadline:
+			timer.Stop()
+			return last, fmt.Errorf("timed out after %s waiting for resource to be ready", timeout)
+		case <-timer.C:
+			cluster, err := getCluster(ctx, client, cp, cn)
 			if err != nil {
-				return err
+				return last, err
 			}
-			if cluster.Status.Status == "Provisioned" {
-				return nil
+			last = cluster
+			if cluster.Status != nil && cluster.Status.Status == "Provisioned" {
+				return cluster, nil
 			}
 		}
 	}
 }
 
+// replaceAttributes are the attributes with a RequiresReplace plan modifier.
+var replaceAttributes = path.Paths{
+	path.Root("name"),
+	path.Root("eckcp"),
+	path.Root("clusternetwork").AtName("nodeprefix"),
+	path.Root("clusternetwork").AtName("podprefix"),
+	path.Root("clusternetwork").AtName("serviceprefix"),
+}
+
+// replacedAttributes returns the attributes whose planned change requires the
+// cluster to be replaced.
+func replacedAttributes(ctx context.Context, req resource.ModifyPlanRequest, diags *diag.Diagnostics) path.Paths {
+	var replaced path.Paths
+
+	for _, p := range replaceAttributes {
+		var planned, current types.String
+		diags.Append(req.Plan.GetAttribute(ctx, p, &planned)...)
+		diags.Append(req.State.GetAttribute(ctx, p, &current)...)
+
+		if !planned.Equal(current) {
+			replaced = append(replaced, p)
+		}
+	}
+
+	return replaced
+}
+
+// waitDurations returns the configured polling interval and timeout used while
+// waiting for a cluster to be provisioned.  The values are checked by the
+// schema validators, so the fallbacks only apply when wait_interval is not
+// configured, or to state written before wait_timeout existed.
+func waitDurations(m clusterModel) (time.Duration, time.Duration) {
+	interval, err := time.ParseDuration(m.WaitInterval.ValueString())
+	if err != nil {
+		interval = 30 * time.Second
+	}
+
+	timeout, err := time.ParseDuration(m.WaitTimeout.ValueString())
+	if err != nil {
+		timeout = 10 * time.Minute
+	}
+
+	return interval, timeout
+}
+
+// waitPolling returns how to poll a cluster while waiting for it, and for how
+// long.  An explicitly configured wait_interval polls at that fixed interval,
+// otherwise polling backs off as configured by the provider.
+func (r *clusterResource) waitPolling(ctx context.Context, config tfsdk.Config, m clusterModel) (pollConfig, time.Duration) {
+	interval, timeout := waitDurations(m)
+
+	var configured types.String
+	if diags := config.GetAttribute(ctx, path.Root("wait_interval"), &configured); diags.HasError() || configured.IsNull() {
+		return r.poll, timeout
+	}
+
+	return fixedPollConfig(interval), timeout
+}
+
 // Create a new resource.
 func (r *clusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Info(ctx, "🦄 Create")
 	// Retrieve values from plan
 	var plan clusterModel
 	var kubeconfig string
+	var current *generated.KubernetesCluster
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// The bundle and images are chosen at plan time, unless the provider was
+	// not yet configured.
+	catalog := newClusterCatalog(r.client)
+
+	resp.Diagnostics.Append(selectImages(ctx, catalog, &plan, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ApplicationBundle.IsUnknown() {
+		defaultApplicationBundle(ctx, catalog, &plan.ApplicationBundle, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	cluster := generateKubernetesCluster(ctx, plan)
+	labelPools(&cluster, nil)
 
-	// Create new cluster
-	ur, err := r.client.PostApiV1ControlplanesControlPlaneNameClusters(ctx, plan.EckCp.ValueString(), cluster)
-	if ur.StatusCode != http.StatusAccepted {
+	body, err := clusterRequestBody(cluster, plan.ExtraFeatures, plan.SpecJSON)
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating cluster",
-			"Could not create cluster, unexpected response from ECK API: "+ur.Status,
+			"Could not encode cluster: "+err.Error(),
 		)
+		return
 	}
+
+	// Create new cluster
+	createCtx, retries := withRetryCount(ctx)
+	ur, err := r.client.PostApiV1ControlplanesControlPlaneNameClustersWithBodyWithResponse(createCtx, plan.EckCp.ValueString(), "application/json", body)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating cluster",
@@ -355,25 +923,93 @@ func (r *clusterResource) Create(ctx context.Context, req resource.CreateRequest
 		)
 		return
 	}
+	// A retried create may have been processed before the API asked for it
+	// to be retried, in which case the conflict is the cluster just created.
+	adopted := ur.StatusCode() == http.StatusConflict && retries.Load() > 0
+	if adopted {
+		tflog.Warn(ctx, "Cluster already exists after retrying create, assuming it was created by this request", map[string]any{
+			"eckcp":   plan.EckCp.ValueString(),
+			"cluster": cluster.Name,
+		})
+	}
+	if ur.StatusCode() == http.StatusConflict && !adopted {
+		resp.Diagnostics.AddError(
+			"Cluster Already Exists",
+			fmt.Sprintf("A cluster named %q already exists in control plane %q.  To manage it with Terraform, import it instead, e.g.:\n\n"+
+				"  terraform import eck_cluster.<name> %s", cluster.Name, plan.EckCp.ValueString(), clusterID(plan.EckCp.ValueString(), cluster.Name)),
+		)
+		return
+	}
+	if !isSuccess(ur.StatusCode()) && !adopted {
+		resp.Diagnostics.AddError(
+			"Error creating cluster",
+			"Could not create cluster, unexpected response from ECK API: "+apiErrorMessage(ur.Status(), ur.Body),
+		)
+		return
+	}
 
 	// Optionally poll for the status
 	if plan.Wait == types.BoolValue(true) {
-		err = waitForResourceToBeReady(ctx, r.client, plan.EckCp.ValueString(), plan.Name.ValueString())
+		poll, timeout := r.waitPolling(ctx, req.Config, plan)
+		current, err = waitForResourceToBeReady(ctx, r.client, plan.EckCp.ValueString(), plan.Name.ValueString(), poll, timeout)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Waiting for Resource to be Ready",
 				err.Error(),
 			)
+		} else {
+			kubeconfig, err = getKubeconfig(ctx, r.client, plan.EckCp.ValueString(), cluster.Name)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Reading Kubeconfig",
+					"Could not read kubeconfig for cluster "+cluster.Name+": "+err.Error(),
+				)
+			} else if plan.WaitForNodes.ValueBool() {
+				err = waitForNodesToBeReady(ctx, kubeconfig, expectedNodes(plan), poll, timeout)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Error Waiting for Nodes to be Ready",
+						err.Error(),
+					)
+				}
+			}
 		}
-		kubeconfig = getKubeconfig(*r.client, ctx, plan.EckCp.ValueString(), cluster.Name)
 	}
 
-	if cluster.Status.Status == "Provisioned" {
-		kubeconfig = getKubeconfig(*r.client, ctx, plan.EckCp.ValueString(), cluster.Name)
+	// The cluster exists even if waiting failed, so record the status reported
+	// by the API and save it to state.  Terraform then taints the cluster
+	// rather than trying to create it again.
+	if current == nil {
+		current, err = getCluster(ctx, r.client, plan.EckCp.ValueString(), cluster.Name)
+		if err != nil {
+			tflog.Warn(ctx, "Could not read status of created cluster", map[string]any{
+				"error": err.Error(),
+			})
+		}
+	}
+	if current != nil {
+		cluster.Status = current.Status
+		// The API chooses network settings which were not configured.
+		cluster.Network = current.Network
 	}
 
 	// Refresh cluster details
-	plan = generateClusterModel(ctx, cluster, plan.EckCp.ValueString(), kubeconfig, plan.Wait.ValueBool())
+	network := plan.ClusterNetwork
+	plan = generateClusterModel(ctx, cluster, kubeconfig, plan)
+
+	// Without the cluster the prefixes chosen by the API are not known, so are
+	// left null until they are refreshed.
+	if current == nil {
+		if network.NodePrefix.IsUnknown() {
+			plan.ClusterNetwork.NodePrefix = types.StringNull()
+		}
+		if network.PodPrefix.IsUnknown() {
+			plan.ClusterNetwork.PodPrefix = types.StringNull()
+		}
+		if network.ServicePrefix.IsUnknown() {
+			plan.ClusterNetwork.ServicePrefix = types.StringNull()
+		}
+	}
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &plan)
@@ -395,7 +1031,12 @@ func (r *clusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	// Get refreshed values from Unikorn
-	kubernetesCluster, err := r.client.GetApiV1ControlplanesControlPlaneNameClustersClusterName(ctx, state.EckCp.ValueString(), state.Name.ValueString())
+	cluster, err := getCluster(ctx, r.client, state.EckCp.ValueString(), state.Name.ValueString())
+	if isNotFound(err) {
+		// The cluster has been deleted outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading cluster information",
@@ -404,40 +1045,38 @@ func (r *clusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	cluster := generated.KubernetesCluster{}
-	err = json.NewDecoder(kubernetesCluster.Body).Decode(&cluster)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to read cluster information",
-			"An error occurred while parsing the response from the ECK API."+
-				"JSON Error: "+err.Error(),
-		)
-	}
-
 	if cluster.Status != nil {
 		var kubeconfig string
-		if cluster.Status.Status == "Provisioned" {
-			kubeconfig = getKubeconfig(*r.client, ctx, state.EckCp.ValueString(), cluster.Name)
-		} else {
-			kubeconfig = ""
+		// Without a stored kubeconfig, it is only fetched to find the API endpoint.
+		missing := !validKubeconfig(state.Kubeconfig)
+		if !state.StoreKubeconfig.IsNull() && !state.StoreKubeconfig.ValueBool() {
+			missing = state.ApiEndpoint.IsNull()
+		}
+
+		if cluster.Status.Status == "Provisioned" && missing {
+			kubeconfig, err = getKubeconfig(ctx, r.client, state.EckCp.ValueString(), cluster.Name)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Reading Kubeconfig",
+					"Could not read kubeconfig for cluster "+cluster.Name+": "+err.Error(),
+				)
+				return
+			}
 		}
 
 		// Refresh cluster details
 		// Overwrite items with refreshed state
-		state = generateClusterModel(ctx, cluster, state.EckCp.ValueString(), kubeconfig, state.Wait.ValueBool())
+		state = generateClusterModel(ctx, *cluster, kubeconfig, state)
 	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
-		resp.Diagnostics.AddError(
-			"Error", "Cannot set state"+err.Error(),
-		)
 		return
 	}
-
 }
+
 func (r *clusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Info(ctx, "🦄 Update")
 	// Retrieve values from plan
@@ -449,38 +1088,129 @@ func (r *clusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// Generate API request body from plan
-	cluster := generateKubernetesCluster(ctx, plan)
+	var state clusterModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Create new cluster
-	_, err := r.client.PutApiV1ControlplanesControlPlaneNameClustersClusterName(ctx, plan.EckCp.ValueString(), plan.Name.ValueString(), cluster)
+	// Images are selected at plan time, unless the provider was not yet
+	// configured.
+	resp.Diagnostics.Append(selectImages(ctx, newClusterCatalog(r.client), &plan, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	steps := []upgradeStep{{description: "cluster", model: plan}}
+	if plan.RollingUpgrade.ValueBool() {
+		steps = rollingUpgradeSteps(ctx, state, plan)
+	}
+
+	// The pools as they are before the update decide which are labelled.
+	prior, err := getCluster(ctx, r.client, plan.EckCp.ValueString(), plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error creating cluster",
-			"Could not create cluster, unexpected error: "+err.Error(),
+			"Error updating cluster",
+			"Could not read cluster "+plan.Name.ValueString()+": "+err.Error(),
 		)
 		return
 	}
 
-	// Optionally poll for the status
-	if plan.Wait == types.BoolValue(true) {
-		err = waitForResourceToBeReady(ctx, r.client, plan.EckCp.ValueString(), plan.Name.ValueString())
+	var cluster generated.KubernetesCluster
+	var current *generated.KubernetesCluster
+
+	for i, step := range steps {
+		tflog.Info(ctx, "Updating "+step.description, map[string]any{
+			"step":  i + 1,
+			"steps": len(steps),
+		})
+
+		// Generate API request body from plan
+		cluster = generateKubernetesCluster(ctx, step.model)
+		labelPools(&cluster, prior)
+
+		body, err := clusterRequestBody(cluster, plan.ExtraFeatures, plan.SpecJSON)
 		if err != nil {
 			resp.Diagnostics.AddError(
-				"Error Waiting for Resource to be Ready",
-				err.Error(),
+				"Error updating cluster",
+				"Could not encode "+step.description+": "+err.Error(),
+			)
+			return
+		}
+
+		// Update cluster
+		ur, err := r.client.PutApiV1ControlplanesControlPlaneNameClustersClusterNameWithBodyWithResponse(ctx, plan.EckCp.ValueString(), plan.Name.ValueString(), "application/json", body)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating cluster",
+				"Could not update "+step.description+", unexpected error: "+err.Error(),
 			)
 			return
 		}
-		kubeconfig = getKubeconfig(*r.client, ctx, plan.EckCp.ValueString(), cluster.Name)
+		if !isSuccess(ur.StatusCode()) {
+			resp.Diagnostics.AddError(
+				"Error updating cluster",
+				"Could not update "+step.description+", unexpected response from ECK API: "+apiErrorMessage(ur.Status(), ur.Body),
+			)
+			return
+		}
+
+		// Optionally poll for the status
+		if plan.Wait == types.BoolValue(true) {
+			poll, timeout := r.waitPolling(ctx, req.Config, plan)
+			current, err = waitForResourceToBeReady(ctx, r.client, plan.EckCp.ValueString(), plan.Name.ValueString(), poll, timeout)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Waiting for Resource to be Ready",
+					"Waiting for "+step.description+": "+err.Error(),
+				)
+				return
+			}
+			kubeconfig, err = getKubeconfig(ctx, r.client, plan.EckCp.ValueString(), cluster.Name)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Reading Kubeconfig",
+					"Could not read kubeconfig for cluster "+cluster.Name+": "+err.Error(),
+				)
+				return
+			}
+			if plan.WaitForNodes.ValueBool() {
+				err = waitForNodesToBeReady(ctx, kubeconfig, expectedNodes(step.model), poll, timeout)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Error Waiting for Nodes to be Ready",
+						"Waiting for "+step.description+": "+err.Error(),
+					)
+					return
+				}
+			}
+		}
+	}
+
+	// Record the status seen while waiting, or read it once when not waiting.
+	if current == nil {
+		var err error
+		current, err = getCluster(ctx, r.client, plan.EckCp.ValueString(), plan.Name.ValueString())
+		if err != nil {
+			tflog.Warn(ctx, "Could not read status of updated cluster", map[string]any{
+				"error": err.Error(),
+			})
+
+			// The planned status is unknown, so leave it to the next refresh.
+			cluster.Status = nil
+		}
+	}
+	if current != nil {
+		cluster.Status = current.Status
 	}
 
-	if cluster.Status.Status == "Provisioned" {
-		kubeconfig = getKubeconfig(*r.client, ctx, plan.EckCp.ValueString(), cluster.Name)
+	// Keep the stored kubeconfig unless the plan expects a new one.
+	if !plan.Kubeconfig.IsUnknown() {
+		kubeconfig = ""
 	}
 
 	// Refresh cluster details
-	plan = generateClusterModel(ctx, cluster, plan.EckCp.ValueString(), kubeconfig, plan.Wait.ValueBool())
+	plan = generateClusterModel(ctx, cluster, kubeconfig, plan)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -490,6 +1220,32 @@ func (r *clusterResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 }
 
+// ImportState imports an existing cluster by its identifier, in the form
+// `eckcp/name`.
+func (r *clusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	eckcp, name, ok := strings.Cut(req.ID, "/")
+	if !ok || eckcp == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: eckcp/name. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("eckcp"), eckcp)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+
+	// Resource-only settings are not returned by the API, so start from their
+	// defaults to avoid an update after import.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_nodes"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rolling_upgrade"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("store_kubeconfig"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_timeout"), "10m")...)
+}
+
 func (r *clusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Info(ctx, "🦄 Delete")
 	// Retrieve values from state
@@ -500,8 +1256,17 @@ func (r *clusterResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Cluster Is Protected From Deletion",
+			"Cluster "+state.Name.ValueString()+" has deletion_protection enabled.  Set deletion_protection to false "+
+				"and apply the change before destroying the cluster.",
+		)
+		return
+	}
+
 	// Delete cluster
-	_, err := r.client.DeleteApiV1ControlplanesControlPlaneNameClustersClusterName(ctx, state.EckCp.ValueString(), state.Name.ValueString())
+	dr, err := r.client.DeleteApiV1ControlplanesControlPlaneNameClustersClusterNameWithResponse(ctx, state.EckCp.ValueString(), state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting cluster",
@@ -509,4 +1274,12 @@ func (r *clusterResource) Delete(ctx context.Context, req resource.DeleteRequest
 		)
 		return
 	}
+	// A cluster which no longer exists has already been deleted.
+	if !isSuccess(dr.StatusCode()) && dr.StatusCode() != http.StatusNotFound {
+		resp.Diagnostics.AddError(
+			"Error deleting cluster",
+			"Could not delete cluster, unexpected response from ECK API: "+apiErrorMessage(dr.Status(), dr.Body),
+		)
+		return
+	}
 }
@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/eschercloudai/eckctl/pkg/generated"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &compatibilityMatrixDataSource{}
+	_ datasource.DataSourceWithConfigure = &compatibilityMatrixDataSource{}
+)
+
+// NewCompatibilityMatrixDataSource is a helper function to simplify the provider implementation.
+func NewCompatibilityMatrixDataSource() datasource.DataSource {
+	return &compatibilityMatrixDataSource{}
+}
+
+// compatibilityMatrixDataSource is the data source implementation.
+type compatibilityMatrixDataSource struct {
+	client *generated.ClientWithResponses
+}
+
+// compatibilityMatrixModel maps the data source schema data.
+type compatibilityMatrixModel struct {
+	ApplicationBundles []bundleCompatibilityModel     `tfsdk:"application_bundles"`
+	KubernetesVersions []kubernetesCompatibilityModel `tfsdk:"kubernetes_versions"`
+}
+
+// bundleCompatibilityModel maps a cluster application bundle.
+type bundleCompatibilityModel struct {
+	Name      types.String `tfsdk:"name"`
+	Version   types.String `tfsdk:"version"`
+	Preview   types.Bool   `tfsdk:"preview"`
+	EndOfLife types.String `tfsdk:"end_of_life"`
+}
+
+// kubernetesCompatibilityModel maps a Kubernetes version and the images providing it.
+type kubernetesCompatibilityModel struct {
+	Version types.String   `tfsdk:"version"`
+	Images  []types.String `tfsdk:"images"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *compatibilityMatrixDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T with value of %v. Please report this issue to the provider developers.", req.ProviderData, req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// Metadata returns the data source type name.
+func (d *compatibilityMatrixDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_compatibility_matrix"
+}
+
+// Schema defines the schema for the data source.
+func (d *compatibilityMatrixDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the cluster application bundles and the Kubernetes versions, with their images, offered by the platform, so modules can compute valid combinations and plan upgrades.  " +
+			"The ECK API does not report which Kubernetes versions an application bundle supports, so bundles and Kubernetes versions are listed separately.",
+		Attributes: map[string]schema.Attribute{
+			"application_bundles": schema.ListNestedAttribute{
+				Description: "The cluster application bundles, ordered by version, oldest first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the bundle, as used by `eck_cluster.applicationbundle`.",
+							Computed:    true,
+						},
+						"version": schema.StringAttribute{
+							Description: "The version of the bundle, e.g. `1.4.1`.",
+							Computed:    true,
+						},
+						"preview": schema.BoolAttribute{
+							Description: "Whether the bundle is a preview release.",
+							Computed:    true,
+						},
+						"end_of_life": schema.StringAttribute{
+							Description: "When the bundle reaches, or reached, end of life, after which clusters using it are upgraded automatically, in RFC 3339 format.  Null if not scheduled.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"kubernetes_versions": schema.ListNestedAttribute{
+				Description: "The Kubernetes versions which images are available for, ordered by version, oldest first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"version": schema.StringAttribute{
+							Description: "The Kubernetes version, e.g. `v1.28.3`.",
+							Computed:    true,
+						},
+						"images": schema.ListAttribute{
+							Description: "The names of the images providing the Kubernetes version, newest first, as selected by the `eck_image` data source.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *compatibilityMatrixDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	br, err := d.client.GetApiV1ApplicationbundlesClusterWithResponse(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to retrieve application bundle information",
+			err.Error(),
+		)
+		return
+	}
+
+	if br.JSON200 == nil {
+		resp.Diagnostics.AddError(
+			"Unable to retrieve application bundle information",
+			newAPIError(br.StatusCode(), br.Status(), br.Body).Error(),
+		)
+		return
+	}
+
+	ir, err := d.client.GetApiV1ProvidersOpenstackImagesWithResponse(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to retrieve image information",
+			err.Error(),
+		)
+		return
+	}
+
+	if ir.JSON200 == nil {
+		resp.Diagnostics.AddError(
+			"Unable to retrieve image information",
+			newAPIError(ir.StatusCode(), ir.Status(), ir.Body).Error(),
+		)
+		return
+	}
+
+	state := compatibilityMatrixModel{
+		ApplicationBundles: bundleCompatibilityModels(*br.JSON200),
+		KubernetesVersions: kubernetesCompatibilityModels(*ir.JSON200),
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// bundleCompatibilityModels returns the application bundles ordered by version.
+func bundleCompatibilityModels(bundles []generated.ApplicationBundle) []bundleCompatibilityModel {
+	sorted := append([]generated.ApplicationBundle{}, bundles...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return compareBundleVersions(sorted[i].Version, sorted[j].Version) < 0
+	})
+
+	models := make([]bundleCompatibilityModel, 0, len(sorted))
+
+	for _, bundle := range sorted {
+		model := bundleCompatibilityModel{
+			Name:      types.StringValue(bundle.Name),
+			Version:   types.StringValue(bundle.Version),
+			Preview:   types.BoolValue(bundle.Preview != nil && *bundle.Preview),
+			EndOfLife: types.StringNull(),
+		}
+
+		if bundle.EndOfLife != nil {
+			model.EndOfLife = types.StringValue(bundle.EndOfLife.Format(time.RFC3339))
+		}
+
+		models = append(models, model)
+	}
+
+	return models
+}
+
+// kubernetesCompatibilityModels groups images by the Kubernetes version they
+// provide, ordering versions oldest first and images newest first.
+func kubernetesCompatibilityModels(images []generated.OpenstackImage) []kubernetesCompatibilityModel {
+	sorted := append([]generated.OpenstackImage{}, images...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Created.After(sorted[j].Created)
+	})
+
+	byVersion := map[string][]types.String{}
+	for _, image := range sorted {
+		version := image.Versions.Kubernetes
+		byVersion[version] = append(byVersion[version], types.StringValue(image.Name))
+	}
+
+	versions := make([]string, 0, len(byVersion))
+	for version := range byVersion {
+		versions = append(versions, version)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		if cmp, err := compareKubernetesVersions(versions[i], versions[j]); err == nil {
+			return cmp < 0
+		}
+
+		return versions[i] < versions[j]
+	})
+
+	models := make([]kubernetesCompatibilityModel, 0, len(versions))
+
+	for _, version := range versions {
+		models = append(models, kubernetesCompatibilityModel{
+			Version: types.StringValue(version),
+			Images:  byVersion[version],
+		})
+	}
+
+	return models
+}
@@ -2,12 +2,13 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/eschercloudai/eckctl/pkg/generated"
@@ -35,17 +36,17 @@ func (d *controlPlaneDataSource) Configure(_ context.Context, req datasource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*generated.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *Client, got: %T with value of %v. Please report this issue to the provider developers.", req.ProviderData, req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T with value of %v. Please report this issue to the provider developers.", req.ProviderData, req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
 }
 
 // Metadata returns the data source type name.
@@ -57,15 +58,41 @@ func (d *controlPlaneDataSource) Metadata(_ context.Context, req datasource.Meta
 func (d *controlPlaneDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list ECK Control Planes whose name matches this regular expression.",
+				Validators: []validator.String{
+					validRegex(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list ECK Control Planes with this provisioning status, e.g. `Provisioned`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(resourceStatuses...),
+				},
+			},
 			"controlplanes": schema.ListNestedAttribute{
 				Computed:    true,
 				Description: "A list of ECK Control Planes.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The identifier of the ECK Control Plane.",
+						},
 						"name": schema.StringAttribute{
 							Computed:    true,
 							Description: "The name of the ECK Control Plane.",
 						},
+						"created_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "The time the ECK Control Plane was created, in RFC 3339 format.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The provisioning status of the ECK Control Plane.",
+						},
 						"applicationbundle": schema.SingleNestedAttribute{
 							Required: true,
 							Attributes: map[string]schema.Attribute{
@@ -77,6 +104,7 @@ func (d *controlPlaneDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 									Required:    true,
 									Description: "Whether automatic upgrades of the ECK Control Plane are enabled.",
 								},
+								"days_of_week": daysOfWeekDataSourceAttribute(),
 							},
 						},
 					},
@@ -88,18 +116,35 @@ func (d *controlPlaneDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 
 // controlPlaneDataSourceModel maps the data source schema data.
 type controlPlaneDataSourceModel struct {
-	Controlplanes []controlPlaneModel `tfsdk:"controlplanes"`
+	NameRegex     types.String            `tfsdk:"name_regex"`
+	Status        types.String            `tfsdk:"status"`
+	Controlplanes []controlPlaneItemModel `tfsdk:"controlplanes"`
 }
 
 // controlPlaneModel maps controlPlane schema data.
 type controlPlaneModel struct {
+	CreatedAt         types.String           `tfsdk:"created_at"`
+	Id                types.String           `tfsdk:"id"`
+	Name              types.String           `tfsdk:"name"`
+	Status            types.String           `tfsdk:"status"`
+	ApplicationBundle applicationBundleModel `tfsdk:"applicationbundle"`
+	ForceDestroy      types.Bool             `tfsdk:"force_destroy"`
+}
+
+// controlPlaneItemModel maps a control plane in the data source, which omits
+// the resource-only settings of controlPlaneModel.
+type controlPlaneItemModel struct {
+	CreatedAt         types.String           `tfsdk:"created_at"`
+	Id                types.String           `tfsdk:"id"`
 	Name              types.String           `tfsdk:"name"`
+	Status            types.String           `tfsdk:"status"`
 	ApplicationBundle applicationBundleModel `tfsdk:"applicationbundle"`
 }
 
 type applicationBundleModel struct {
-	Version     types.String `tfsdk:"version"`
-	AutoUpgrade types.Bool   `tfsdk:"autoupgrade"`
+	Version     types.String     `tfsdk:"version"`
+	AutoUpgrade types.Bool       `tfsdk:"autoupgrade"`
+	DaysOfWeek  *daysOfWeekModel `tfsdk:"days_of_week"`
 }
 
 func IsDaysOfWeekSet(aba *generated.ApplicationBundleAutoUpgrade) bool {
@@ -113,32 +158,60 @@ func IsDaysOfWeekSet(aba *generated.ApplicationBundleAutoUpgrade) bool {
 func (d *controlPlaneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state controlPlaneDataSourceModel
 
-	r, err := d.client.GetApiV1Controlplanes(ctx)
-	if err != nil {
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if r.StatusCode != http.StatusOK {
-		fmt.Printf("Error retrieving control plane information, %v", r.StatusCode)
-		return
+	// The pattern has already been checked by validRegex.
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		nameRegex = regexp.MustCompile(state.NameRegex.ValueString())
 	}
-	controlPlanes := generated.ControlPlanes{}
-	err = json.NewDecoder(r.Body).Decode(&controlPlanes)
+
+	r, err := d.client.GetApiV1ControlplanesWithResponse(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to read control plane information",
-			"An error occurred while parsing the response from the ECK API."+
-				"JSON Error: "+err.Error(),
+			"Unable to retrieve control plane information",
+			err.Error(),
+		)
+		return
+	}
+
+	if r.JSON200 == nil {
+		resp.Diagnostics.AddError(
+			"Unable to retrieve control plane information",
+			newAPIError(r.StatusCode(), r.Status(), r.Body).Error(),
 		)
+		return
 	}
 
 	// Map response body to model
-	for _, controlPlane := range controlPlanes {
-		controlPlaneState := controlPlaneModel{
-			Name: types.StringValue(controlPlane.Name),
+	state.Controlplanes = []controlPlaneItemModel{}
+
+	for _, controlPlane := range *r.JSON200 {
+		if nameRegex != nil && !nameRegex.MatchString(controlPlane.Name) {
+			continue
+		}
+
+		if !state.Status.IsNull() && (controlPlane.Status == nil || controlPlane.Status.Status != state.Status.ValueString()) {
+			continue
+		}
+
+		var daysOfWeek *daysOfWeekModel
+		if controlPlane.ApplicationBundleAutoUpgrade != nil {
+			daysOfWeek = generateDaysOfWeekModel(controlPlane.ApplicationBundleAutoUpgrade.DaysOfWeek)
+		}
+
+		controlPlaneState := controlPlaneItemModel{
+			CreatedAt: creationTime(controlPlane.Status),
+			Id:        types.StringValue(controlPlane.Name),
+			Name:      types.StringValue(controlPlane.Name),
+			Status:    resourceStatus(controlPlane.Status),
 			ApplicationBundle: applicationBundleModel{
 				Version:     types.StringValue(controlPlane.ApplicationBundle.Name),
 				AutoUpgrade: types.BoolValue(IsDaysOfWeekSet(controlPlane.ApplicationBundleAutoUpgrade)),
+				DaysOfWeek:  daysOfWeek,
 			},
 		}
 
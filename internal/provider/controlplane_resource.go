@@ -2,23 +2,28 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/eschercloudai/eckctl/pkg/generated"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &controlPlaneResource{}
-	_ resource.ResourceWithConfigure = &controlPlaneResource{}
+	_ resource.Resource                = &controlPlaneResource{}
+	_ resource.ResourceWithConfigure   = &controlPlaneResource{}
+	_ resource.ResourceWithImportState = &controlPlaneResource{}
+	_ resource.ResourceWithModifyPlan  = &controlPlaneResource{}
 )
 
 // NewControlPlaneResource is a helper function to simplify the provider implementation.
@@ -37,18 +42,18 @@ func (r *controlPlaneResource) Configure(_ context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*generated.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *generated.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 // Metadata returns the resource type name.
@@ -60,9 +65,28 @@ func (r *controlPlaneResource) Metadata(_ context.Context, req resource.Metadata
 func (r *controlPlaneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the ECK Control Plane, which is its name.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description: "The time the ECK Control Plane was created, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "The provisioning status of the ECK Control Plane.",
+				Computed:    true,
+			},
 			"name": schema.StringAttribute{
-				Description: "The name of the ECK Control Plane.",
+				Description: "The name of the ECK Control Plane.  Must be a valid DNS label.",
 				Required:    true,
+				Validators:  dnsLabelValidators(),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -71,33 +95,84 @@ func (r *controlPlaneResource) Schema(_ context.Context, _ resource.SchemaReques
 				Required: true,
 				Attributes: map[string]schema.Attribute{
 					"version": schema.StringAttribute{
-						Description: "The version of the ECK Control Plane. Defaults to 1.4.0.",
+						Description: "The version of the ECK Control Plane.  Defaults to the newest version which is neither in preview nor end of life when the control plane is created.",
 						Computed:    true,
 						Optional:    true,
-						Default:     stringdefault.StaticString("1.4.0"),
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
 					},
 					"autoupgrade": schema.BoolAttribute{
-						Description: "Whether automatic upgrades of the ECK Control Plane are enabled. If enabled, perform upgrades randomly from Monday-Friday 00:00-07:00 UTC.",
+						Description: "Whether automatic upgrades of the ECK Control Plane are enabled. If enabled, perform upgrades randomly within `days_of_week`, or Monday-Friday 00:00-07:00 UTC if unset.",
 						Required:    true,
 					},
+					"days_of_week": daysOfWeekAttribute(),
 				},
 			},
+			"force_destroy": schema.BoolAttribute{
+				Description: "Whether to delete any clusters in the ECK Control Plane when it is destroyed.  If false, destroying a control plane which still has clusters fails.",
+				Computed:    true,
+				Optional:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 		},
 	}
 }
 
-// Create a new resource.
-func (r *controlPlaneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	// Retrieve values from plan
-	var plan controlPlaneModel
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+// getControlPlane fetches a control plane from the ECK API.
+func getControlPlane(ctx context.Context, client *generated.ClientWithResponses, name string) (*generated.ControlPlane, error) {
+	r, err := client.GetApiV1ControlplanesControlPlaneNameWithResponse(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.JSON200 == nil {
+		return nil, newAPIError(r.StatusCode(), r.Status(), r.Body)
+	}
+
+	return r.JSON200, nil
+}
+
+// activeClusterNames returns the names of the clusters in a control plane
+// which are not already being deleted.
+func activeClusterNames(ctx context.Context, client *generated.ClientWithResponses, eckcp string) ([]string, error) {
+	r, err := client.GetApiV1ControlplanesControlPlaneNameClustersWithResponse(ctx, eckcp)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.JSON200 == nil {
+		return nil, newAPIError(r.StatusCode(), r.Status(), r.Body)
+	}
+
+	var names []string
+
+	for _, cluster := range *r.JSON200 {
+		if cluster.Status != nil && (cluster.Status.DeletionTime != nil || cluster.Status.Status == "Deprovisioning") {
+			continue
+		}
+
+		names = append(names, cluster.Name)
+	}
+
+	return names, nil
+}
+
+// generateControlPlaneAutoUpgrade renders the auto-upgrade settings of a
+// control plane for the API.
+func generateControlPlaneAutoUpgrade(m applicationBundleModel) *generated.ApplicationBundleAutoUpgrade {
+	if !m.AutoUpgrade.ValueBool() {
+		return nil
+	}
+
+	if m.DaysOfWeek != nil {
+		return &generated.ApplicationBundleAutoUpgrade{
+			DaysOfWeek: generateDaysOfWeek(m.DaysOfWeek),
+		}
 	}
 
 	// Match the default specified in the UI
-	upgradeWindow := &generated.ApplicationBundleAutoUpgrade{
+	return &generated.ApplicationBundleAutoUpgrade{
 		DaysOfWeek: &generated.AutoUpgradeDaysOfWeek{
 			Monday: &generated.TimeWindow{
 				Start: 0,
@@ -121,6 +196,38 @@ func (r *controlPlaneResource) Create(ctx context.Context, req resource.CreateRe
 			},
 		},
 	}
+}
+
+// generateAutoUpgradeDaysOfWeekModel renders the auto-upgrade window of a
+// control plane for Terraform state.  The window is only tracked when one was
+// configured, as the API fills in a default when upgrades are enabled without
+// one, and omits it entirely when they are disabled.
+func generateAutoUpgradeDaysOfWeekModel(aba *generated.ApplicationBundleAutoUpgrade, prior *daysOfWeekModel) *daysOfWeekModel {
+	if aba == nil || prior == nil {
+		return prior
+	}
+
+	return generateDaysOfWeekModel(aba.DaysOfWeek)
+}
+
+// Create a new resource.
+func (r *controlPlaneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan controlPlaneModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The version is chosen at plan time, unless the provider was not yet
+	// configured.
+	if plan.ApplicationBundle.Version.IsUnknown() {
+		r.defaultVersion(ctx, &plan.ApplicationBundle.Version, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Generate API request body from plan
 	controlplane := generated.ControlPlane{
@@ -129,11 +236,12 @@ func (r *controlPlaneResource) Create(ctx context.Context, req resource.CreateRe
 			Name:    "control-plane-" + plan.ApplicationBundle.Version.ValueString(),
 			Version: plan.ApplicationBundle.Version.ValueString(),
 		},
-		ApplicationBundleAutoUpgrade: upgradeWindow,
+		ApplicationBundleAutoUpgrade: generateControlPlaneAutoUpgrade(plan.ApplicationBundle),
 	}
 
 	// Create new controlplane
-	_, err := r.client.PostApiV1ControlplanesWithResponse(ctx, controlplane)
+	createCtx, retries := withRetryCount(ctx)
+	cr, err := r.client.PostApiV1ControlplanesWithResponse(createCtx, controlplane)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating controlplane",
@@ -141,14 +249,56 @@ func (r *controlPlaneResource) Create(ctx context.Context, req resource.CreateRe
 		)
 		return
 	}
+	// A retried create may have been processed before the API asked for it
+	// to be retried, in which case the conflict is the control plane just
+	// created.
+	adopted := cr.StatusCode() == http.StatusConflict && retries.Load() > 0
+	if adopted {
+		tflog.Warn(ctx, "Control plane already exists after retrying create, assuming it was created by this request", map[string]any{
+			"eckcp": controlplane.Name,
+		})
+	}
+	if cr.StatusCode() == http.StatusConflict && !adopted {
+		resp.Diagnostics.AddError(
+			"Control Plane Already Exists",
+			fmt.Sprintf("A control plane named %q already exists.  To manage it with Terraform, import it instead, e.g.:\n\n"+
+				"  terraform import eck_controlplane.<name> %s", controlplane.Name, controlplane.Name),
+		)
+		return
+	}
+	if !isSuccess(cr.StatusCode()) && !adopted {
+		resp.Diagnostics.AddError(
+			"Error creating controlplane",
+			"Could not create controlplane, unexpected response from ECK API: "+apiErrorMessage(cr.Status(), cr.Body),
+		)
+		return
+	}
+
+	// The control plane exists even if it cannot be read, so save it to state
+	// and leave the status to the next refresh.
+	createdAt, status := types.StringNull(), types.StringNull()
+
+	current, err := getControlPlane(ctx, r.client, controlplane.Name)
+	if err != nil {
+		tflog.Warn(ctx, "Could not read status of created control plane", map[string]any{
+			"error": err.Error(),
+		})
+	} else {
+		createdAt, status = creationTime(current.Status), resourceStatus(current.Status)
+	}
 
 	// Map response body to schema and populate Computed attribute values
 	plan = controlPlaneModel{
-		Name: types.StringValue(controlplane.Name),
+		CreatedAt: createdAt,
+		Id:        types.StringValue(controlplane.Name),
+		Name:      types.StringValue(controlplane.Name),
+		Status:    status,
 		ApplicationBundle: applicationBundleModel{
 			Version:     types.StringValue(controlplane.ApplicationBundle.Version),
 			AutoUpgrade: types.BoolValue(IsDaysOfWeekSet(controlplane.ApplicationBundleAutoUpgrade)),
+			DaysOfWeek:  generateAutoUpgradeDaysOfWeekModel(controlplane.ApplicationBundleAutoUpgrade, plan.ApplicationBundle.DaysOfWeek),
 		},
+		ForceDestroy: plan.ForceDestroy,
 	}
 
 	// Set state to fully populated data
@@ -161,16 +311,30 @@ func (r *controlPlaneResource) Create(ctx context.Context, req resource.CreateRe
 
 // Read resource information.
 func (r *controlPlaneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	// Get current state
+	// Get current state.  Only the name is known after an import, so read the
+	// attributes individually rather than the whole model.
 	var state controlPlaneModel
-	diags := req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
+	var priorDaysOfWeek *daysOfWeekModel
+	var forceDestroy types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &state.Name)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("applicationbundle").AtName("days_of_week"), &priorDaysOfWeek)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("force_destroy"), &forceDestroy)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// State written by earlier versions of the provider has no force_destroy.
+	if forceDestroy.IsNull() {
+		forceDestroy = types.BoolValue(false)
+	}
+
 	// Get refreshed values from Unikorn
-	controlplanes, err := r.client.GetApiV1ControlplanesControlPlaneName(ctx, state.Name.ValueString())
+	controlPlane, err := getControlPlane(ctx, r.client, state.Name.ValueString())
+	if isNotFound(err) {
+		// The control plane has been deleted outside of Terraform.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Control Plane information",
@@ -179,32 +343,24 @@ func (r *controlPlaneResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	controlPlane := generated.ControlPlane{}
-	err = json.NewDecoder(controlplanes.Body).Decode(&controlPlane)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to read control plane information",
-			"An error occurred while parsing the response from the ECK API."+
-				"JSON Error: "+err.Error(),
-		)
-	}
-
 	// Overwrite items with refreshed state
 	state = controlPlaneModel{
-		Name: types.StringValue(controlPlane.Name),
+		CreatedAt: creationTime(controlPlane.Status),
+		Id:        types.StringValue(controlPlane.Name),
+		Name:      types.StringValue(controlPlane.Name),
+		Status:    resourceStatus(controlPlane.Status),
 		ApplicationBundle: applicationBundleModel{
 			Version:     types.StringValue(controlPlane.ApplicationBundle.Version),
 			AutoUpgrade: types.BoolValue(IsDaysOfWeekSet(controlPlane.ApplicationBundleAutoUpgrade)),
+			DaysOfWeek:  generateAutoUpgradeDaysOfWeekModel(controlPlane.ApplicationBundleAutoUpgrade, priorDaysOfWeek),
 		},
+		ForceDestroy: forceDestroy,
 	}
 
 	// Set refreshed state
-	diags = resp.State.Set(ctx, &state)
+	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
-		resp.Diagnostics.AddError(
-			"Error", "Cannot set state"+err.Error(),
-		)
 		return
 	}
 }
@@ -221,37 +377,35 @@ func (r *controlPlaneResource) Update(ctx context.Context, req resource.UpdateRe
 	var state controlPlaneModel
 	req.State.Get(ctx, &state)
 
-	var u generated.ApplicationBundleAutoUpgrade
-
 	// Generate API request body from plan
 	controlplane := generated.ControlPlane{
 		Name: plan.Name.ValueString(),
 		ApplicationBundle: generated.ApplicationBundle{
 			Name:    "control-plane-" + plan.ApplicationBundle.Version.ValueString(),
-			Version: plan.ApplicationBundle.Version.String(),
+			Version: plan.ApplicationBundle.Version.ValueString(),
 		},
-		ApplicationBundleAutoUpgrade: &u,
+		ApplicationBundleAutoUpgrade: generateControlPlaneAutoUpgrade(plan.ApplicationBundle),
 	}
 
 	// Update controlplane
 	h, err := r.client.PutApiV1ControlplanesControlPlaneNameWithResponse(ctx, state.Name.ValueString(), controlplane)
-	if h.HTTPResponse.StatusCode != http.StatusOK {
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating controlplane",
-			"Received unexpected HTTP response: "+fmt.Sprintf("%v", h.HTTPResponse.StatusCode),
+			"Could not update controlplane, unexpected error: "+err.Error(),
 		)
+		return
 	}
-
-	if err != nil {
+	if !isSuccess(h.StatusCode()) {
 		resp.Diagnostics.AddError(
 			"Error updating controlplane",
-			"Could not update controlplane, unexpected error: "+err.Error(),
+			"Could not update controlplane, unexpected response from ECK API: "+apiErrorMessage(h.Status(), h.Body),
 		)
 		return
 	}
 
 	// Get refreshed values from API
-	controlplanes, err := r.client.GetApiV1ControlplanesControlPlaneName(ctx, plan.Name.ValueString())
+	controlPlane, err := getControlPlane(ctx, r.client, plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Control Plane information",
@@ -260,23 +414,23 @@ func (r *controlPlaneResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	controlPlane := generated.ControlPlane{}
-	err = json.NewDecoder(controlplanes.Body).Decode(&controlPlane)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to read control plane information",
-			"An error occurred while parsing the response from the ECK API."+
-				"JSON Error: "+err.Error(),
-		)
+	createdAt := creationTime(controlPlane.Status)
+	if createdAt.IsNull() {
+		createdAt = state.CreatedAt
 	}
 
 	// Map response body to schema and populate Computed attribute values
 	plan = controlPlaneModel{
-		Name: types.StringValue(controlplane.Name),
+		CreatedAt: createdAt,
+		Id:        types.StringValue(controlplane.Name),
+		Name:      types.StringValue(controlplane.Name),
+		Status:    resourceStatus(controlPlane.Status),
 		ApplicationBundle: applicationBundleModel{
 			AutoUpgrade: types.BoolValue(IsDaysOfWeekSet(controlPlane.ApplicationBundleAutoUpgrade)),
+			DaysOfWeek:  generateAutoUpgradeDaysOfWeekModel(controlPlane.ApplicationBundleAutoUpgrade, plan.ApplicationBundle.DaysOfWeek),
 			Version:     types.StringValue(controlplane.ApplicationBundle.Version),
 		},
+		ForceDestroy: plan.ForceDestroy,
 	}
 
 	diags = resp.State.Set(ctx, plan)
@@ -286,6 +440,82 @@ func (r *controlPlaneResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 }
 
+// defaultVersion sets an unconfigured control plane version to the newest
+// supported version.
+func (r *controlPlaneResource) defaultVersion(ctx context.Context, version *types.String, diags *diag.Diagnostics) {
+	latest, err := latestControlPlaneBundle(ctx, r.client)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("applicationbundle").AtName("version"),
+			"Error Selecting Control Plane Version",
+			"Could not select the default control plane version, set applicationbundle.version explicitly: "+err.Error(),
+		)
+		return
+	}
+
+	*version = types.StringValue(latest)
+}
+
+// ModifyPlan selects the default version of a new control plane, and warns
+// when a control plane which still has clusters is planned to be destroyed, as
+// the destroy will fail unless the clusters are destroyed first, e.g. by the
+// same configuration, or force_destroy is set.
+func (r *controlPlaneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The client is not configured when the provider configuration depends on
+	// values which are not yet known.
+	if r.client == nil {
+		return
+	}
+
+	if !req.Plan.Raw.IsNull() {
+		versionPath := path.Root("applicationbundle").AtName("version")
+
+		var version types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, versionPath, &version)...)
+		if resp.Diagnostics.HasError() || !version.IsUnknown() {
+			return
+		}
+
+		r.defaultVersion(ctx, &version, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, versionPath, version)...)
+
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state controlPlaneModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || state.ForceDestroy.ValueBool() {
+		return
+	}
+
+	clusters, err := activeClusterNames(ctx, r.client, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Check Control Plane Clusters",
+			"Could not list clusters in control plane "+state.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if len(clusters) == 0 {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Control Plane Has Clusters",
+		fmt.Sprintf("Control plane %s still has clusters: %s.  Destroying it will fail unless they are destroyed first, "+
+			"or force_destroy is set to true to delete them along with the control plane.", state.Name.ValueString(), strings.Join(clusters, ", ")),
+	)
+}
+
 func (r *controlPlaneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from state
 	var state controlPlaneModel
@@ -295,8 +525,49 @@ func (r *controlPlaneResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	clusters, err := activeClusterNames(ctx, r.client, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Control Plane",
+			"Could not list clusters in control plane "+state.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if len(clusters) > 0 && !state.ForceDestroy.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Control Plane Has Clusters",
+			fmt.Sprintf("Control plane %s still has clusters: %s.  Destroy the clusters first, or set force_destroy to true "+
+				"and apply the change to delete them along with the control plane.", state.Name.ValueString(), strings.Join(clusters, ", ")),
+		)
+		return
+	}
+
+	for _, cluster := range clusters {
+		tflog.Info(ctx, "Deleting cluster in control plane", map[string]any{
+			"eckcp":   state.Name.ValueString(),
+			"cluster": cluster,
+		})
+
+		dr, err := r.client.DeleteApiV1ControlplanesControlPlaneNameClustersClusterNameWithResponse(ctx, state.Name.ValueString(), cluster)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Control Plane",
+				"Could not delete cluster "+cluster+", unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if !isSuccess(dr.StatusCode()) && dr.StatusCode() != http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"Error Deleting Control Plane",
+				"Could not delete cluster "+cluster+", unexpected response from ECK API: "+apiErrorMessage(dr.Status(), dr.Body),
+			)
+			return
+		}
+	}
+
 	// Delete existing control plane
-	_, err := r.client.DeleteApiV1ControlplanesControlPlaneName(ctx, state.Name.ValueString())
+	dr, err := r.client.DeleteApiV1ControlplanesControlPlaneNameWithResponse(ctx, state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Control Plane",
@@ -304,4 +575,19 @@ func (r *controlPlaneResource) Delete(ctx context.Context, req resource.DeleteRe
 		)
 		return
 	}
+	// A control plane which no longer exists has already been deleted.
+	if !isSuccess(dr.StatusCode()) && dr.StatusCode() != http.StatusNotFound {
+		resp.Diagnostics.AddError(
+			"Error Deleting Control Plane",
+			"Could not delete control plane, unexpected response from ECK API: "+apiErrorMessage(dr.Status(), dr.Body),
+		)
+		return
+	}
+}
+
+// ImportState imports an existing control plane by its name.
+func (r *controlPlaneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
 }
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &decodeKubeconfigFunction{}
+)
+
+// NewDecodeKubeconfigFunction is a helper function to simplify the provider implementation.
+func NewDecodeKubeconfigFunction() function.Function {
+	return &decodeKubeconfigFunction{}
+}
+
+// decodeKubeconfigFunction is the function implementation.
+type decodeKubeconfigFunction struct{}
+
+// kubeconfig is the subset of a kubeconfig file needed to connect to a cluster.
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKeyData         string `yaml:"client-key-data"`
+			Token                 string `yaml:"token"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+}
+
+// decodedKubeconfigModel maps the function result data.
+type decodedKubeconfigModel struct {
+	Host                 types.String `tfsdk:"host"`
+	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	ClientCertificate    types.String `tfsdk:"client_certificate"`
+	ClientKey            types.String `tfsdk:"client_key"`
+	Token                types.String `tfsdk:"token"`
+}
+
+// Metadata returns the function name.
+func (f *decodeKubeconfigFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "decode_kubeconfig"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *decodeKubeconfigFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Decode the connection details of a cluster kubeconfig.",
+		Description: "Decodes the current context of a kubeconfig, such as `eck_cluster.kubeconfig`, into the `host`, `cluster_ca_certificate`, `client_certificate`, `client_key` and `token` arguments used to configure the kubernetes and helm providers.  Certificates and keys are returned PEM encoded, and attributes missing from the kubeconfig are null.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "kubeconfig",
+				Description: "Kubeconfig file contents.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"host":                   types.StringType,
+				"cluster_ca_certificate": types.StringType,
+				"client_certificate":     types.StringType,
+				"client_key":             types.StringType,
+				"token":                  types.StringType,
+			},
+		},
+	}
+}
+
+// Run decodes the kubeconfig.
+func (f *decodeKubeconfigFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &data))
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := decodeKubeconfig(data)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid kubeconfig: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, &result))
+}
+
+// decodeKubeconfig extracts the server and credentials of the current context
+// of a kubeconfig, falling back to the first cluster and user when there is no
+// current context.
+func decodeKubeconfig(data string) (decodedKubeconfigModel, error) {
+	var config kubeconfig
+	if err := yaml.Unmarshal([]byte(data), &config); err != nil {
+		return decodedKubeconfigModel{}, err
+	}
+
+	if len(config.Clusters) == 0 {
+		return decodedKubeconfigModel{}, fmt.Errorf("no clusters defined")
+	}
+
+	clusterIndex, userIndex := 0, 0
+
+	if config.CurrentContext != "" {
+		found := false
+
+		for _, c := range config.Contexts {
+			if c.Name != config.CurrentContext {
+				continue
+			}
+
+			found = true
+
+			for i := range config.Clusters {
+				if config.Clusters[i].Name == c.Context.Cluster {
+					clusterIndex = i
+				}
+			}
+
+			for i := range config.Users {
+				if config.Users[i].Name == c.Context.User {
+					userIndex = i
+				}
+			}
+		}
+
+		if !found {
+			return decodedKubeconfigModel{}, fmt.Errorf("current context %q is not defined", config.CurrentContext)
+		}
+	}
+
+	cluster := config.Clusters[clusterIndex].Cluster
+
+	caCertificate, err := decodeBase64Value(cluster.CertificateAuthorityData)
+	if err != nil {
+		return decodedKubeconfigModel{}, fmt.Errorf("certificate-authority-data: %w", err)
+	}
+
+	result := decodedKubeconfigModel{
+		Host:                 stringValueOrNull(cluster.Server),
+		ClusterCACertificate: caCertificate,
+		ClientCertificate:    types.StringNull(),
+		ClientKey:            types.StringNull(),
+		Token:                types.StringNull(),
+	}
+
+	if len(config.Users) > 0 {
+		user := config.Users[userIndex].User
+
+		if result.ClientCertificate, err = decodeBase64Value(user.ClientCertificateData); err != nil {
+			return decodedKubeconfigModel{}, fmt.Errorf("client-certificate-data: %w", err)
+		}
+
+		if result.ClientKey, err = decodeBase64Value(user.ClientKeyData); err != nil {
+			return decodedKubeconfigModel{}, fmt.Errorf("client-key-data: %w", err)
+		}
+
+		result.Token = stringValueOrNull(user.Token)
+	}
+
+	return result, nil
+}
+
+// decodeBase64Value decodes base64 kubeconfig data, which is null when unset.
+func decodeBase64Value(s string) (types.String, error) {
+	if s == "" {
+		return types.StringNull(), nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return types.StringNull(), err
+	}
+
+	return types.StringValue(string(data)), nil
+}
+
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(s)
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testRunFunction calls a provider function as Terraform does, returning its
+// result and error.
+func testRunFunction(t *testing.T, f function.Function, arguments ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var definition function.DefinitionResponse
+	f.Definition(ctx, function.DefinitionRequest{}, &definition)
+
+	result, funcErr := definition.Definition.Return.NewResultData(ctx)
+	if funcErr != nil {
+		t.Fatalf("unable to create result: %s", funcErr)
+	}
+
+	resp := function.RunResponse{Result: result}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(arguments)}, &resp)
+
+	return resp.Result.Value(), resp.Error
+}
+
+// testFuncError returns the error expected for an invalid function argument.
+func testFuncError(argument int64, text string) *function.FuncError {
+	return function.NewArgumentFuncError(argument, text)
+}
+
+func TestDecodeKubeconfigFunction(t *testing.T) {
+	t.Parallel()
+
+	encode := func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	}
+
+	certificateConfig := `
+apiVersion: v1
+kind: Config
+current-context: admin@cluster
+clusters:
+- name: other
+  cluster:
+    server: https://other.example.com:6443
+- name: cluster
+  cluster:
+    server: https://cluster.example.com:6443
+    certificate-authority-data: ` + encode("ca") + `
+users:
+- name: other
+  user:
+    token: other
+- name: admin
+  user:
+    client-certificate-data: ` + encode("certificate") + `
+    client-key-data: ` + encode("key") + `
+contexts:
+- name: other@other
+  context:
+    cluster: other
+    user: other
+- name: admin@cluster
+  context:
+    cluster: cluster
+    user: admin
+`
+
+	tokenConfig := `
+clusters:
+- name: cluster
+  cluster:
+    server: https://cluster.example.com:6443
+users:
+- name: admin
+  user:
+    token: secret
+`
+
+	result := func(host, ca, certificate, key, token string) attr.Value {
+		return types.ObjectValueMust(
+			map[string]attr.Type{
+				"host":                   types.StringType,
+				"cluster_ca_certificate": types.StringType,
+				"client_certificate":     types.StringType,
+				"client_key":             types.StringType,
+				"token":                  types.StringType,
+			},
+			map[string]attr.Value{
+				"host":                   stringValueOrNull(host),
+				"cluster_ca_certificate": stringValueOrNull(ca),
+				"client_certificate":     stringValueOrNull(certificate),
+				"client_key":             stringValueOrNull(key),
+				"token":                  stringValueOrNull(token),
+			},
+		)
+	}
+
+	tests := []struct {
+		name       string
+		kubeconfig string
+		want       attr.Value
+		wantErr    *function.FuncError
+	}{
+		{
+			name:       "client certificate",
+			kubeconfig: certificateConfig,
+			want:       result("https://cluster.example.com:6443", "ca", "certificate", "key", ""),
+		},
+		{
+			name:       "token without current context",
+			kubeconfig: tokenConfig,
+			want:       result("https://cluster.example.com:6443", "", "", "", "secret"),
+		},
+		{
+			name:       "no users",
+			kubeconfig: "clusters:\n- name: cluster\n  cluster:\n    server: https://cluster.example.com:6443\n",
+			want:       result("https://cluster.example.com:6443", "", "", "", ""),
+		},
+		{
+			name:       "empty",
+			kubeconfig: "",
+			wantErr:    testFuncError(0, "Invalid kubeconfig: no clusters defined"),
+		},
+		{
+			name:       "not YAML",
+			kubeconfig: "clusters: [",
+			wantErr:    testFuncError(0, "Invalid kubeconfig: yaml: line 1: did not find expected node content"),
+		},
+		{
+			name:       "undefined current context",
+			kubeconfig: "current-context: missing\n" + tokenConfig,
+			wantErr:    testFuncError(0, `Invalid kubeconfig: current context "missing" is not defined`),
+		},
+		{
+			name:       "invalid certificate authority",
+			kubeconfig: "clusters:\n- name: cluster\n  cluster:\n    certificate-authority-data: \"!\"\n",
+			wantErr:    testFuncError(0, "Invalid kubeconfig: certificate-authority-data: illegal base64 data at input byte 0"),
+		},
+		{
+			name:       "invalid client key",
+			kubeconfig: "clusters:\n- name: cluster\nusers:\n- name: admin\n  user:\n    client-key-data: \"!\"\n",
+			wantErr:    testFuncError(0, "Invalid kubeconfig: client-key-data: illegal base64 data at input byte 0"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := testRunFunction(t, NewDecodeKubeconfigFunction(), types.StringValue(test.kubeconfig))
+			if !err.Equal(test.wantErr) {
+				t.Fatalf("error = %v, want %v", err, test.wantErr)
+			}
+
+			if test.wantErr == nil && !got.Equal(test.want) {
+				t.Errorf("result = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// eckctlConfig is the configuration file of the eckctl CLI, ~/.eckctl.yaml by
+// default.  eckctl does not cache tokens, it exchanges the credentials on
+// every invocation, so only the credentials can be read.
+type eckctlConfig struct {
+	URL      string `yaml:"url"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	Project  string `yaml:"project"`
+	Insecure bool   `yaml:"insecure"`
+}
+
+// readEckctlConfig reads an eckctl configuration file, expanding a leading ~
+// to the user's home directory.
+func readEckctlConfig(name string) (*eckctlConfig, error) {
+	if name == "~" || strings.HasPrefix(name, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+
+		name = filepath.Join(home, strings.TrimPrefix(name, "~"))
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &eckctlConfig{}
+
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
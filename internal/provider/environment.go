@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// applyEnvironment sets provider attributes which are not configured from
+// their ECK_* environment variables, so that CI pipelines can configure the
+// provider without any values in HCL.  Environment variables are validated as
+// the schema would validate the attributes.  Credentials, host and region are
+// handled by Configure as their precedence depends on each other.
+func applyEnvironment(config *eckProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(envBool(&config.Insecure, "insecure", "ECK_INSECURE")...)
+	diags.Append(envDuration(&config.RequestTimeout, "request_timeout", "ECK_REQUEST_TIMEOUT")...)
+	diags.Append(envInt64(&config.MaxRetries, "max_retries", "ECK_MAX_RETRIES", 0)...)
+	diags.Append(envDuration(&config.RetryWaitMin, "retry_wait_min", "ECK_RETRY_WAIT_MIN")...)
+	diags.Append(envDuration(&config.RetryWaitMax, "retry_wait_max", "ECK_RETRY_WAIT_MAX")...)
+	diags.Append(envFloat64(&config.RateLimit, "rate_limit", "ECK_RATE_LIMIT", 0)...)
+	diags.Append(envInt64(&config.RateLimitBurst, "rate_limit_burst", "ECK_RATE_LIMIT_BURST", 1)...)
+	diags.Append(envDuration(&config.PollIntervalMin, "poll_interval_min", "ECK_POLL_INTERVAL_MIN")...)
+	diags.Append(envDuration(&config.PollIntervalMax, "poll_interval_max", "ECK_POLL_INTERVAL_MAX")...)
+	diags.Append(envHeaders(&config.Headers, "headers", "ECK_HEADERS")...)
+
+	diags.Append(envPEM(&config.CACert, &config.CACertFile, "ECK_CA_CERT", "ECK_CA_CERT_FILE")...)
+	diags.Append(envPEM(&config.ClientCert, &config.ClientCertFile, "ECK_CLIENT_CERT", "ECK_CLIENT_CERT_FILE")...)
+	diags.Append(envPEM(&config.ClientKey, &config.ClientKeyFile, "ECK_CLIENT_KEY", "ECK_CLIENT_KEY_FILE")...)
+
+	return diags
+}
+
+// secretFromFile returns a secret read from the file configured by the file
+// attribute.  Otherwise, when the secret is not already set, it is read from
+// the file named by the environment variable, if any.
+func secretFromFile(secret string, file types.String, name string) (string, error) {
+	filename := os.Getenv(name)
+	if !file.IsNull() {
+		filename = file.ValueString()
+	} else if secret != "" {
+		return secret, nil
+	}
+
+	if filename == "" {
+		return secret, nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// invalidEnvironment reports an environment variable which cannot be used
+// for a provider attribute.
+func invalidEnvironment(attribute string, name string, description string, value string) diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(
+		path.Root(attribute),
+		"Invalid Environment Variable",
+		fmt.Sprintf("Environment variable %s %s, got: %s", name, description, value),
+	)
+}
+
+// envString sets an unconfigured string attribute from an environment
+// variable.
+func envString(value *types.String, name string) {
+	if s := os.Getenv(name); value.IsNull() && s != "" {
+		*value = types.StringValue(s)
+	}
+}
+
+// envPEM sets unconfigured PEM data, which may be given either inline or as a
+// file, from either environment variable, but only when neither attribute is
+// configured.
+func envPEM(value *types.String, file *types.String, name string, fileName string) diag.Diagnostics {
+	if !value.IsNull() || !file.IsNull() {
+		return nil
+	}
+
+	if os.Getenv(name) != "" && os.Getenv(fileName) != "" {
+		return diag.Diagnostics{diag.NewErrorDiagnostic(
+			"Invalid Environment Variable",
+			"Only one of the "+name+" and "+fileName+" environment variables may be set.",
+		)}
+	}
+
+	envString(value, name)
+	envString(file, fileName)
+
+	return nil
+}
+
+// envDuration sets an unconfigured duration attribute from an environment
+// variable, which must be a positive duration.
+func envDuration(value *types.String, attribute string, name string) diag.Diagnostics {
+	s := os.Getenv(name)
+	if !value.IsNull() || s == "" {
+		return nil
+	}
+
+	if d, err := time.ParseDuration(s); err != nil || d <= 0 {
+		return diag.Diagnostics{invalidEnvironment(attribute, name, "must be a positive duration such as `30s` or `10m`", s)}
+	}
+
+	*value = types.StringValue(s)
+
+	return nil
+}
+
+// envBool sets an unconfigured boolean attribute from an environment
+// variable, e.g. `true` or `1`.
+func envBool(value *types.Bool, attribute string, name string) diag.Diagnostics {
+	s := os.Getenv(name)
+	if !value.IsNull() || s == "" {
+		return nil
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return diag.Diagnostics{invalidEnvironment(attribute, name, "must be a boolean such as `true` or `false`", s)}
+	}
+
+	*value = types.BoolValue(b)
+
+	return nil
+}
+
+// envInt64 sets an unconfigured integer attribute from an environment
+// variable, which must be at least minimum.
+func envInt64(value *types.Int64, attribute string, name string, minimum int64) diag.Diagnostics {
+	s := os.Getenv(name)
+	if !value.IsNull() || s == "" {
+		return nil
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || i < minimum {
+		return diag.Diagnostics{invalidEnvironment(attribute, name, fmt.Sprintf("must be an integer of at least %d", minimum), s)}
+	}
+
+	*value = types.Int64Value(i)
+
+	return nil
+}
+
+// envFloat64 sets an unconfigured number attribute from an environment
+// variable, which must be at least minimum.
+func envFloat64(value *types.Float64, attribute string, name string, minimum float64) diag.Diagnostics {
+	s := os.Getenv(name)
+	if !value.IsNull() || s == "" {
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < minimum {
+		return diag.Diagnostics{invalidEnvironment(attribute, name, fmt.Sprintf("must be a number of at least %g", minimum), s)}
+	}
+
+	*value = types.Float64Value(f)
+
+	return nil
+}
+
+// envHeaders sets unconfigured HTTP headers from an environment variable of
+// comma separated `Name=value` pairs, e.g. `X-Api-Key=abc,X-Tenant=t1`.
+func envHeaders(value *types.Map, attribute string, name string) diag.Diagnostics {
+	s := os.Getenv(name)
+	if !value.IsNull() || s == "" {
+		return nil
+	}
+
+	headers := map[string]attr.Value{}
+
+	for _, pair := range strings.Split(s, ",") {
+		header, headerValue, ok := strings.Cut(pair, "=")
+		header = strings.TrimSpace(header)
+
+		if !ok || !headerNamePattern.MatchString(header) || strings.EqualFold(header, "Authorization") || strings.EqualFold(header, "User-Agent") {
+			return diag.Diagnostics{invalidEnvironment(attribute, name, "must be comma separated `Name=value` pairs of HTTP headers other than Authorization and User-Agent", header)}
+		}
+
+		headers[header] = types.StringValue(strings.TrimSpace(headerValue))
+	}
+
+	m, diags := types.MapValue(types.StringType, headers)
+	if diags.HasError() {
+		return diags
+	}
+
+	*value = m
+
+	return nil
+}
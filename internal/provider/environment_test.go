@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Environment variables are process wide, so these tests set them with
+// t.Setenv and cannot run in parallel.
+
+// testProviderModel returns a provider configuration with nothing configured.
+func testProviderModel() eckProviderModel {
+	return eckProviderModel{
+		Host:            types.StringNull(),
+		Region:          types.StringNull(),
+		Username:        types.StringNull(),
+		Password:        types.StringNull(),
+		PasswordFile:    types.StringNull(),
+		Project:         types.StringNull(),
+		Token:           types.StringNull(),
+		TokenFile:       types.StringNull(),
+		Insecure:        types.BoolNull(),
+		CACert:          types.StringNull(),
+		CACertFile:      types.StringNull(),
+		ClientCert:      types.StringNull(),
+		ClientCertFile:  types.StringNull(),
+		ClientKey:       types.StringNull(),
+		ClientKeyFile:   types.StringNull(),
+		RequestTimeout:  types.StringNull(),
+		MaxRetries:      types.Int64Null(),
+		RetryWaitMin:    types.StringNull(),
+		RetryWaitMax:    types.StringNull(),
+		RateLimit:       types.Float64Null(),
+		RateLimitBurst:  types.Int64Null(),
+		PollIntervalMin: types.StringNull(),
+		PollIntervalMax: types.StringNull(),
+		EckctlConfig:    types.StringNull(),
+		Headers:         types.MapNull(types.StringType),
+	}
+}
+
+// testProviderModelDiff returns the names of the attributes which differ
+// between two provider configurations.
+func testProviderModelDiff(a eckProviderModel, b eckProviderModel) []string {
+	var diff []string
+
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	for i := 0; i < av.NumField(); i++ {
+		if !av.Field(i).Interface().(attr.Value).Equal(bv.Field(i).Interface().(attr.Value)) {
+			diff = append(diff, av.Type().Field(i).Tag.Get("tfsdk"))
+		}
+	}
+
+	return diff
+}
+
+// testEnvironment sets environment variables for the duration of a test.
+func testEnvironment(t *testing.T, environment map[string]string) {
+	t.Helper()
+
+	for name, value := range environment {
+		t.Setenv(name, value)
+	}
+}
+
+func TestApplyEnvironment(t *testing.T) {
+	testEnvironment(t, map[string]string{
+		"ECK_INSECURE":          "1",
+		"ECK_REQUEST_TIMEOUT":   "45s",
+		"ECK_MAX_RETRIES":       "0",
+		"ECK_RETRY_WAIT_MIN":    "2s",
+		"ECK_RETRY_WAIT_MAX":    "1m",
+		"ECK_RATE_LIMIT":        "2.5",
+		"ECK_RATE_LIMIT_BURST":  "5",
+		"ECK_POLL_INTERVAL_MIN": "5s",
+		"ECK_POLL_INTERVAL_MAX": "1m",
+		"ECK_HEADERS":           "X-Api-Key=abc, X-Tenant = t1",
+		"ECK_CA_CERT_FILE":      "/etc/eck/ca.pem",
+		"ECK_CLIENT_CERT":       "certificate",
+		"ECK_CLIENT_KEY":        "key",
+	})
+
+	config := testProviderModel()
+
+	if diags := applyEnvironment(&config); diags.HasError() {
+		t.Fatalf("applyEnvironment() = %v", diags)
+	}
+
+	headers, _ := types.MapValue(types.StringType, map[string]attr.Value{
+		"X-Api-Key": types.StringValue("abc"),
+		"X-Tenant":  types.StringValue("t1"),
+	})
+
+	want := testProviderModel()
+	want.Insecure = types.BoolValue(true)
+	want.RequestTimeout = types.StringValue("45s")
+	want.MaxRetries = types.Int64Value(0)
+	want.RetryWaitMin = types.StringValue("2s")
+	want.RetryWaitMax = types.StringValue("1m")
+	want.RateLimit = types.Float64Value(2.5)
+	want.RateLimitBurst = types.Int64Value(5)
+	want.PollIntervalMin = types.StringValue("5s")
+	want.PollIntervalMax = types.StringValue("1m")
+	want.Headers = headers
+	want.CACertFile = types.StringValue("/etc/eck/ca.pem")
+	want.ClientCert = types.StringValue("certificate")
+	want.ClientKey = types.StringValue("key")
+
+	if diff := testProviderModelDiff(config, want); diff != nil {
+		t.Errorf("applyEnvironment() set %v to %+v, want %+v", diff, config, want)
+	}
+}
+
+func TestApplyEnvironmentConfigPrecedence(t *testing.T) {
+	testEnvironment(t, map[string]string{
+		"ECK_INSECURE":         "true",
+		"ECK_REQUEST_TIMEOUT":  "45s",
+		"ECK_MAX_RETRIES":      "not-a-number",
+		"ECK_RATE_LIMIT":       "2.5",
+		"ECK_HEADERS":          "X-Api-Key=abc",
+		"ECK_CA_CERT":          "environment",
+		"ECK_CLIENT_CERT_FILE": "/etc/eck/client.pem",
+		"ECK_CLIENT_KEY":       "key",
+		"ECK_CLIENT_KEY_FILE":  "/etc/eck/client-key.pem",
+	})
+
+	headers, _ := types.MapValue(types.StringType, map[string]attr.Value{
+		"X-Tenant": types.StringValue("t1"),
+	})
+
+	config := testProviderModel()
+	config.Insecure = types.BoolValue(false)
+	config.RequestTimeout = types.StringValue("10s")
+	config.MaxRetries = types.Int64Value(2)
+	config.RateLimit = types.Float64Value(1)
+	config.Headers = headers
+	config.CACertFile = types.StringValue("/etc/ssl/ca.pem")
+	config.ClientCert = types.StringValue("configured")
+	config.ClientKeyFile = types.StringValue("/etc/ssl/client-key.pem")
+
+	want := config
+
+	// Invalid environment variables are ignored when the attribute is
+	// configured, as are conflicting ones.
+	if diags := applyEnvironment(&config); diags.HasError() {
+		t.Fatalf("applyEnvironment() = %v", diags)
+	}
+
+	if diff := testProviderModelDiff(config, want); diff != nil {
+		t.Errorf("applyEnvironment() set %v to %+v, want %+v", diff, config, want)
+	}
+}
+
+func TestApplyEnvironmentInvalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		attribute string
+	}{
+		{name: "ECK_INSECURE", value: "yes", attribute: "insecure"},
+		{name: "ECK_REQUEST_TIMEOUT", value: "30", attribute: "request_timeout"},
+		{name: "ECK_REQUEST_TIMEOUT", value: "-5s", attribute: "request_timeout"},
+		{name: "ECK_REQUEST_TIMEOUT", value: "0s", attribute: "request_timeout"},
+		{name: "ECK_MAX_RETRIES", value: "-1", attribute: "max_retries"},
+		{name: "ECK_MAX_RETRIES", value: "3.5", attribute: "max_retries"},
+		{name: "ECK_RETRY_WAIT_MIN", value: "soon", attribute: "retry_wait_min"},
+		{name: "ECK_RETRY_WAIT_MAX", value: "1 minute", attribute: "retry_wait_max"},
+		{name: "ECK_RATE_LIMIT", value: "fast", attribute: "rate_limit"},
+		{name: "ECK_RATE_LIMIT", value: "-1", attribute: "rate_limit"},
+		{name: "ECK_RATE_LIMIT_BURST", value: "0", attribute: "rate_limit_burst"},
+		{name: "ECK_POLL_INTERVAL_MIN", value: "5", attribute: "poll_interval_min"},
+		{name: "ECK_POLL_INTERVAL_MAX", value: "never", attribute: "poll_interval_max"},
+		{name: "ECK_HEADERS", value: "X-Api-Key", attribute: "headers"},
+		{name: "ECK_HEADERS", value: "Authorization=Bearer abc", attribute: "headers"},
+		{name: "ECK_HEADERS", value: "user-agent=curl", attribute: "headers"},
+		{name: "ECK_HEADERS", value: "X Api Key=abc", attribute: "headers"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name+"="+test.value, func(t *testing.T) {
+			t.Setenv(test.name, test.value)
+
+			config := testProviderModel()
+
+			diags := applyEnvironment(&config)
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("applyEnvironment() = %v, want one error", diags)
+			}
+
+			withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+			if !ok || !withPath.Path().Equal(path.Root(test.attribute)) {
+				t.Errorf("error = %v, want an error for %s", diags.Errors()[0], test.attribute)
+			}
+		})
+	}
+}
+
+func TestApplyEnvironmentPEMConflict(t *testing.T) {
+	testEnvironment(t, map[string]string{
+		"ECK_CA_CERT":      "ca",
+		"ECK_CA_CERT_FILE": "/etc/eck/ca.pem",
+	})
+
+	config := testProviderModel()
+
+	diags := applyEnvironment(&config)
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Invalid Environment Variable" {
+		t.Fatalf("applyEnvironment() = %v, want one error", diags)
+	}
+
+	if !config.CACert.IsNull() || !config.CACertFile.IsNull() {
+		t.Errorf("ca_cert = %s, ca_cert_file = %s, want both null", config.CACert, config.CACertFile)
+	}
+}
+
+func TestSecretFromFile(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(name string, content string) string {
+		filename := filepath.Join(dir, name)
+		if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		return filename
+	}
+
+	configured := write("configured", "from-config\n")
+	environment := write("environment", "from-env\r\n")
+
+	tests := []struct {
+		name    string
+		secret  string
+		file    types.String
+		env     string
+		want    string
+		wantErr bool
+	}{
+		{name: "nothing set", file: types.StringNull()},
+		{name: "secret", secret: "secret", file: types.StringNull(), want: "secret"},
+		{name: "file", file: types.StringValue(configured), want: "from-config"},
+		{name: "file over secret", secret: "secret", file: types.StringValue(configured), want: "from-config"},
+		{name: "environment file", file: types.StringNull(), env: environment, want: "from-env"},
+		{name: "secret over environment file", secret: "secret", file: types.StringNull(), env: environment, want: "secret"},
+		{name: "file over environment file", file: types.StringValue(configured), env: environment, want: "from-config"},
+		{name: "missing file", file: types.StringValue(filepath.Join(dir, "missing")), wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("ECK_TEST_SECRET_FILE", test.env)
+
+			got, err := secretFromFile(test.secret, test.file, "ECK_TEST_SECRET_FILE")
+			if (err != nil) != test.wantErr {
+				t.Fatalf("secretFromFile() error = %v, want error %t", err, test.wantErr)
+			}
+
+			if got != test.want {
+				t.Errorf("secretFromFile() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
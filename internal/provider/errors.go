@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/eschercloudai/eckctl/pkg/generated"
+)
+
+// maxErrorBodyLength limits how much of a non-JSON error body is included in
+// diagnostics, as proxies may return entire HTML pages.
+const maxErrorBodyLength = 512
+
+// isSuccess reports whether an HTTP status code indicates success.
+func isSuccess(statusCode int) bool {
+	return statusCode >= 200 && statusCode < 300
+}
+
+// apiError is returned when the ECK API responds with an unexpected status.
+type apiError struct {
+	statusCode int
+	message    string
+}
+
+// newAPIError creates an error describing an unexpected ECK API response.
+func newAPIError(statusCode int, status string, body []byte) error {
+	return &apiError{
+		statusCode: statusCode,
+		message:    apiErrorMessage(status, body),
+	}
+}
+
+func (e *apiError) Error() string {
+	return "unexpected response from ECK API: " + e.message
+}
+
+// isNotFound reports whether an error is an ECK API 404 response.
+func isNotFound(err error) bool {
+	var e *apiError
+
+	return errors.As(err, &e) && e.statusCode == http.StatusNotFound
+}
+
+// apiErrorMessage describes an unexpected ECK API response, including the
+// error and description returned by the API in the response body when present.
+func apiErrorMessage(status string, body []byte) string {
+	var payload generated.Oauth2Error
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Error != "" {
+		message := status + ": " + string(payload.Error)
+		if payload.ErrorDescription != "" {
+			message += ": " + payload.ErrorDescription
+		}
+
+		return message
+	}
+
+	text := strings.TrimSpace(string(body))
+	if text == "" {
+		return status
+	}
+
+	if len(text) > maxErrorBodyLength {
+		text = text[:maxErrorBodyLength] + "..."
+	}
+
+	return status + ": " + text
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/eschercloudai/eckctl/pkg/generated"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// managedFeatures are the API feature flags with first-class attributes under
+// clusterfeatures, which must not be set through extra_features.
+var managedFeatures = map[string]string{
+	"autoscaling":         "autoscaling",
+	"certManager":         "certmanager",
+	"fileStorage":         "longhorn",
+	"ingress":             "ingress",
+	"kubernetesDashboard": "dashboard",
+	"prometheus":          "prometheus",
+}
+
+// clusterRequestBody renders a cluster as an ECK API request body.  Extra
+// feature flags are merged into the features object as raw JSON, so flags
+// added to the API after the generated client are passed through, then the
+// raw spec, if any, is deep merged over the result.
+func clusterRequestBody(cluster generated.KubernetesCluster, extraFeatures types.Map, specJSON types.String) (io.Reader, error) {
+	body, err := json.Marshal(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	hasFeatures := !extraFeatures.IsNull() && !extraFeatures.IsUnknown() && len(extraFeatures.Elements()) != 0
+	hasSpec := !specJSON.IsNull() && !specJSON.IsUnknown()
+
+	if !hasFeatures && !hasSpec {
+		return bytes.NewReader(body), nil
+	}
+
+	object, err := decodeJSONObject(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasFeatures {
+		features, _ := object["features"].(map[string]any)
+		if features == nil {
+			features = map[string]any{}
+		}
+
+		for name, value := range extraFeatures.Elements() {
+			if enabled, ok := value.(types.Bool); ok && !enabled.IsNull() && !enabled.IsUnknown() {
+				features[name] = enabled.ValueBool()
+			}
+		}
+
+		object["features"] = features
+	}
+
+	if hasSpec {
+		spec, err := decodeJSONObject([]byte(specJSON.ValueString()))
+		if err != nil {
+			return nil, err
+		}
+
+		mergeJSONObjects(object, spec)
+	}
+
+	body, err = json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(body), nil
+}
+
+// decodeJSONObject decodes a JSON object, keeping numbers as written so that
+// values are passed through unchanged.
+func decodeJSONObject(data []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+
+	if object == nil {
+		return nil, errors.New("value must be a JSON object")
+	}
+
+	return object, nil
+}
+
+// mergeJSONObjects deep merges src over dst.  Objects are merged key by key,
+// any other value, including arrays, replaces the existing value, and null
+// removes it.
+func mergeJSONObjects(dst map[string]any, src map[string]any) {
+	for key, value := range src {
+		if value == nil {
+			delete(dst, key)
+			continue
+		}
+
+		srcObject, srcIsObject := value.(map[string]any)
+		dstObject, dstIsObject := dst[key].(map[string]any)
+
+		if srcIsObject && dstIsObject {
+			mergeJSONObjects(dstObject, srcObject)
+			continue
+		}
+
+		dst[key] = value
+	}
+}
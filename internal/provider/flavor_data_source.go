@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/eschercloudai/eckctl/pkg/generated"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &flavorDataSource{}
+	_ datasource.DataSourceWithConfigure = &flavorDataSource{}
+)
+
+// NewFlavorDataSource is a helper function to simplify the provider implementation.
+func NewFlavorDataSource() datasource.DataSource {
+	return &flavorDataSource{}
+}
+
+// flavorDataSource is the data source implementation.
+type flavorDataSource struct {
+	client *generated.ClientWithResponses
+}
+
+// flavorDataSourceModel maps the data source schema data.
+type flavorDataSourceModel struct {
+	MinCPUs   types.Int64  `tfsdk:"min_cpus"`
+	MinMemory types.Int64  `tfsdk:"min_memory"`
+	GPUs      types.Int64  `tfsdk:"gpus"`
+	Name      types.String `tfsdk:"name"`
+	Id        types.String `tfsdk:"id"`
+	CPUs      types.Int64  `tfsdk:"cpus"`
+	Memory    types.Int64  `tfsdk:"memory"`
+	Disk      types.Int64  `tfsdk:"disk"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *flavorDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T with value of %v. Please report this issue to the provider developers.", req.ProviderData, req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// Metadata returns the data source type name.
+func (d *flavorDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_flavor"
+}
+
+// Schema defines the schema for the data source.
+func (d *flavorDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Selects the smallest flavor meeting CPU, memory and GPU requirements, so that configurations do not hard code flavor names which differ between regions.  " +
+			"The ECK API does not report GPU models, so flavors can only be selected by GPU count.",
+		Attributes: map[string]schema.Attribute{
+			"min_cpus": schema.Int64Attribute{
+				Description: "The minimum number of vCPUs the flavor must have.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"min_memory": schema.Int64Attribute{
+				Description: "The minimum amount of memory, in GiB, the flavor must have.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"gpus": schema.Int64Attribute{
+				Description: "The exact number of GPUs the flavor must have.  Defaults to `0`, so GPU flavors are only selected when requested.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the selected flavor.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The ID of the selected flavor.",
+				Computed:    true,
+			},
+			"cpus": schema.Int64Attribute{
+				Description: "The number of vCPUs of the selected flavor.",
+				Computed:    true,
+			},
+			"memory": schema.Int64Attribute{
+				Description: "The amount of memory, in GiB, of the selected flavor.",
+				Computed:    true,
+			},
+			"disk": schema.Int64Attribute{
+				Description: "The amount of ephemeral disk, in GB, of the selected flavor.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *flavorDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state flavorDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r, err := d.client.GetApiV1ProvidersOpenstackFlavorsWithResponse(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to retrieve flavor information",
+			err.Error(),
+		)
+		return
+	}
+
+	if r.JSON200 == nil {
+		resp.Diagnostics.AddError(
+			"Unable to retrieve flavor information",
+			newAPIError(r.StatusCode(), r.Status(), r.Body).Error(),
+		)
+		return
+	}
+
+	flavor := selectFlavor(*r.JSON200, int(state.MinCPUs.ValueInt64()), int(state.MinMemory.ValueInt64()), int(state.GPUs.ValueInt64()))
+	if flavor == nil {
+		resp.Diagnostics.AddError(
+			"No Matching Flavor",
+			fmt.Sprintf("No flavor has at least %d vCPUs, at least %d GiB of memory and exactly %d GPUs.",
+				state.MinCPUs.ValueInt64(), state.MinMemory.ValueInt64(), state.GPUs.ValueInt64()),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(flavor.Name)
+	state.Id = types.StringValue(flavor.Id)
+	state.CPUs = types.Int64Value(int64(flavor.Cpus))
+	state.Memory = types.Int64Value(int64(flavor.Memory))
+	state.Disk = types.Int64Value(int64(flavor.Disk))
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// selectFlavor returns the smallest flavor, by vCPUs then memory, with at
+// least the given vCPUs and memory and exactly the given number of GPUs.
+// Flavors of the same size are ordered by name so the selection is stable.
+func selectFlavor(flavors []generated.OpenstackFlavor, minCPUs int, minMemory int, gpus int) *generated.OpenstackFlavor {
+	var matches []generated.OpenstackFlavor
+
+	for _, flavor := range flavors {
+		flavorGPUs := 0
+		if flavor.Gpus != nil {
+			flavorGPUs = *flavor.Gpus
+		}
+
+		if flavor.Cpus >= minCPUs && flavor.Memory >= minMemory && flavorGPUs == gpus {
+			matches = append(matches, flavor)
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Cpus != matches[j].Cpus {
+			return matches[i].Cpus < matches[j].Cpus
+		}
+
+		if matches[i].Memory != matches[j].Memory {
+			return matches[i].Memory < matches[j].Memory
+		}
+
+		return matches[i].Name < matches[j].Name
+	})
+
+	return &matches[0]
+}
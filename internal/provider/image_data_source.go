@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/eschercloudai/eckctl/pkg/generated"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &imageDataSource{}
+	_ datasource.DataSourceWithConfigure = &imageDataSource{}
+)
+
+// NewImageDataSource is a helper function to simplify the provider implementation.
+func NewImageDataSource() datasource.DataSource {
+	return &imageDataSource{}
+}
+
+// imageDataSource is the data source implementation.
+type imageDataSource struct {
+	client *generated.ClientWithResponses
+}
+
+// imageDataSourceModel maps the data source schema data.
+type imageDataSourceModel struct {
+	Version             types.String `tfsdk:"version"`
+	Name                types.String `tfsdk:"name"`
+	Id                  types.String `tfsdk:"id"`
+	CreatedAt           types.String `tfsdk:"created_at"`
+	NvidiaDriverVersion types.String `tfsdk:"nvidia_driver_version"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *imageDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T with value of %v. Please report this issue to the provider developers.", req.ProviderData, req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+// Metadata returns the data source type name.
+func (d *imageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_image"
+}
+
+// Schema defines the schema for the data source.
+func (d *imageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Selects the newest signed ECK image for a Kubernetes version, so that bumping the version also selects the matching image.  " +
+			"The ECK API does not report image architectures, so images cannot be selected by architecture.",
+		Attributes: map[string]schema.Attribute{
+			"version": schema.StringAttribute{
+				Description: "The Kubernetes version the image must provide, e.g. `v1.28.3`.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(kubernetesVersionPattern, "Must be a Kubernetes version such as v1.28.3"),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the newest image providing the Kubernetes version.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The ID of the image.",
+				Computed:    true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "When the image was created, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"nvidia_driver_version": schema.StringAttribute{
+				Description: "The version of the NVIDIA driver installed in the image, for GPU workload pools.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *imageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state imageDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	image, err := getLatestImage(ctx, d.client, state.Version.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to retrieve image information",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(image.Name)
+	state.Id = types.StringValue(image.Id)
+	state.CreatedAt = types.StringValue(image.Created.Format(time.RFC3339))
+	state.NvidiaDriverVersion = stringValueOrNull(image.Versions.NvidiaDriver)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// getLatestImage returns the most recently created image providing a
+// Kubernetes version.  The ECK API only lists signed images which are
+// compatible with the platform.
+func getLatestImage(ctx context.Context, client *generated.ClientWithResponses, version string) (*generated.OpenstackImage, error) {
+	r, err := client.GetApiV1ProvidersOpenstackImagesWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.JSON200 == nil {
+		return nil, newAPIError(r.StatusCode(), r.Status(), r.Body)
+	}
+
+	return latestImage(*r.JSON200, version)
+}
+
+// latestImage returns the most recently created of the images providing a
+// Kubernetes version.
+func latestImage(images generated.OpenstackImages, version string) (*generated.OpenstackImage, error) {
+	var latest *generated.OpenstackImage
+
+	for i := range images {
+		image := &images[i]
+
+		if image.Versions.Kubernetes != version {
+			continue
+		}
+
+		if latest == nil || image.Created.After(latest.Created) {
+			latest = image
+		}
+	}
+
+	if latest == nil {
+		return nil, fmt.Errorf("no image found for Kubernetes version %s", version)
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// imageSelection is an image attribute which may be selected automatically
+// from its Kubernetes version.
+type imageSelection struct {
+	path    path.Path
+	version types.String
+	image   *types.String
+	// currentImage and currentVersion are null unless the machines exist.
+	currentImage   types.String
+	currentVersion types.String
+}
+
+// imageSelections returns the image attributes of a planned cluster which are
+// selected automatically.
+func imageSelections(plan *clusterModel, state *clusterModel) []imageSelection {
+	var selections []imageSelection
+
+	if plan.ControlPlane != nil && plan.ControlPlane.ImageAutoSelect.ValueBool() {
+		selection := imageSelection{
+			path:           path.Root("controlplane").AtName("image"),
+			version:        plan.ControlPlane.Version,
+			image:          &plan.ControlPlane.Image,
+			currentImage:   types.StringNull(),
+			currentVersion: types.StringNull(),
+		}
+
+		if state != nil && state.ControlPlane != nil {
+			selection.currentImage = state.ControlPlane.Image
+			selection.currentVersion = state.ControlPlane.Version
+		}
+
+		selections = append(selections, selection)
+	}
+
+	for i := range plan.WorkloadNodePools {
+		pool := &plan.WorkloadNodePools[i]
+		if !pool.ImageAutoSelect.ValueBool() {
+			continue
+		}
+
+		selection := imageSelection{
+			path:           path.Root("workloadnodepools").AtListIndex(i).AtName("image"),
+			version:        pool.Version,
+			image:          &pool.Image,
+			currentImage:   types.StringNull(),
+			currentVersion: types.StringNull(),
+		}
+
+		if state != nil {
+			for _, current := range state.WorkloadNodePools {
+				if current.Name.Equal(pool.Name) {
+					selection.currentImage = current.Image
+					selection.currentVersion = current.Version
+				}
+			}
+		}
+
+		selections = append(selections, selection)
+	}
+
+	return selections
+}
+
+// selectImages sets the unknown, automatically selected images of a planned
+// cluster to the newest image for their Kubernetes version.  Machines whose
+// version is unchanged keep their current image, so publishing a new image
+// does not replace every node on the next apply.
+func selectImages(ctx context.Context, catalog *clusterCatalog, plan *clusterModel, state *clusterModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, selection := range imageSelections(plan, state) {
+		// Images are only unknown when the machines have changed, otherwise
+		// they are the images in state.
+		if !selection.image.IsUnknown() || selection.version.IsUnknown() {
+			continue
+		}
+
+		if selection.version.Equal(selection.currentVersion) && !selection.currentImage.IsNull() {
+			*selection.image = selection.currentImage
+			continue
+		}
+
+		images, err := catalog.listImages(ctx)
+		if err != nil {
+			diags.AddAttributeError(
+				selection.path,
+				"Error Selecting Image",
+				"Could not select an image for Kubernetes version "+selection.version.ValueString()+": "+err.Error(),
+			)
+			continue
+		}
+
+		image, err := latestImage(images, selection.version.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				selection.path,
+				"Error Selecting Image",
+				"Could not select an image for Kubernetes version "+selection.version.ValueString()+": "+err.Error(),
+			)
+			continue
+		}
+
+		*selection.image = types.StringValue(image.Name)
+	}
+
+	return diags
+}
@@ -3,13 +3,14 @@ package provider
 import (
 	"context"
 	"fmt"
-	"io"
-	"net/http"
+	"time"
 
 	"github.com/eschercloudai/eckctl/pkg/generated"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -26,6 +27,7 @@ func NewKubeconfigDataSource() datasource.DataSource {
 // coffeesDataSource is the data source implementation.
 type kubeconfigDataSource struct {
 	client *generated.ClientWithResponses
+	poll   pollConfig
 }
 
 // Metadata returns the data source type name.
@@ -37,15 +39,39 @@ func (d *kubeconfigDataSource) Metadata(_ context.Context, req datasource.Metada
 func (d *kubeconfigDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "The name of the ECK cluster.  Defaults to `terratest` for compatibility with earlier versions of the provider.",
+				Optional:    true,
+			},
+			"eckcp": schema.StringAttribute{
+				Description: "The associated ECK Control Plane for the cluster.  Defaults to `tftest` for compatibility with earlier versions of the provider.",
+				Optional:    true,
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Whether to keep retrying until the kubeconfig is available, e.g. while the cluster is still being provisioned.",
+				Optional:    true,
+			},
+			"wait_timeout": schema.StringAttribute{
+				Description: "How long to retry for before giving up, e.g. `10m`.  Defaults to `10m`.",
+				Optional:    true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
 			"kubeconfig": schema.StringAttribute{
-				Computed: true,
+				Description: "The kubeconfig for the cluster.",
+				Computed:    true,
 			},
 		},
 	}
 }
 
 type kubeconfigModel struct {
-	Kubeconfig types.String `tfsdk:"kubeconfig"`
+	Name        types.String `tfsdk:"name"`
+	EckCp       types.String `tfsdk:"eckcp"`
+	Wait        types.Bool   `tfsdk:"wait"`
+	WaitTimeout types.String `tfsdk:"wait_timeout"`
+	Kubeconfig  types.String `tfsdk:"kubeconfig"`
 }
 
 // Configure adds the provider configured client to the data source.
@@ -54,44 +80,93 @@ func (d *kubeconfigDataSource) Configure(_ context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*generated.ClientWithResponses)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *Client, got: %T with value of %v. Please report this issue to the provider developers.", req.ProviderData, req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T with value of %v. Please report this issue to the provider developers.", req.ProviderData, req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
+	d.poll = data.poll
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (d *kubeconfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state kubeconfigModel
 
-	r, err := d.client.GetApiV1ControlplanesControlPlaneNameClustersClusterNameKubeconfig(ctx, "tftest", "terratest")
-	if err != nil {
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if r.StatusCode != http.StatusOK {
-		return
+	eckcp, name := "tftest", "terratest"
+	if !state.EckCp.IsNull() {
+		eckcp = state.EckCp.ValueString()
+	}
+	if !state.Name.IsNull() {
+		name = state.Name.ValueString()
 	}
 
-	body, err := io.ReadAll(r.Body)
+	var kubeconfig string
+	var err error
+	if state.Wait.ValueBool() {
+		timeout := 10 * time.Minute
+		if !state.WaitTimeout.IsNull() {
+			timeout, _ = time.ParseDuration(state.WaitTimeout.ValueString())
+		}
+
+		kubeconfig, err = waitForKubeconfig(ctx, d.client, eckcp, name, d.poll, timeout)
+	} else {
+		kubeconfig, err = getKubeconfig(ctx, d.client, eckcp, name)
+	}
 	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to retrieve kubeconfig",
+			"Could not read kubeconfig for cluster "+name+": "+err.Error(),
+		)
 		return
 	}
 
-	state := kubeconfigModel{
-		Kubeconfig: types.StringValue((string(body))),
-	}
+	state.Kubeconfig = types.StringValue(kubeconfig)
 
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+}
 
+// waitForKubeconfig retries fetching the kubeconfig of a cluster until it
+// succeeds, as the kubeconfig is not available until the cluster is
+// provisioned, and may briefly fail after that.
+func waitForKubeconfig(ctx context.Context, client *generated.ClientWithResponses, eckcp string, cluster string, poll pollConfig, timeout time.Duration) (string, error) {
+	deadline := time.After(timeout)
+	schedule := poll.schedule()
+
+	for {
+		kubeconfig, err := getKubeconfig(ctx, client, eckcp, cluster)
+		if err == nil {
+			return kubeconfig, nil
+		}
+
+		tflog.Debug(ctx, "Waiting for kubeconfig", map[string]any{
+			"error": err.Error(),
+		})
+
+		timer := time.NewTimer(schedule.Next())
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", fmt.Errorf("operation was canceled")
+		case <-deadline:
+			timer.Stop()
+			return "", fmt.Errorf("timed out after %s: %w", timeout, err)
+		case <-timer.C:
+		}
+	}
 }
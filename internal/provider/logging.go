@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// correlationHeaders are response headers which identify a request in the
+// logs of the ECK API or a proxy in front of it.
+var correlationHeaders = []string{
+	"X-Request-Id",
+	"X-Correlation-Id",
+}
+
+// loggingTransport is an http.RoundTripper which logs each ECK API request at
+// debug level, so TF_LOG=debug shows what the provider is doing.  Bodies and
+// headers are not logged as they contain credentials.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func newLoggingTransport(next http.RoundTripper) *loggingTransport {
+	return &loggingTransport{
+		next: next,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+
+	resp, err := t.next.RoundTrip(req)
+
+	fields := map[string]any{
+		"method":   req.Method,
+		"host":     req.URL.Host,
+		"path":     req.URL.Path,
+		"duration": time.Since(start).String(),
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "ECK API request failed", fields)
+
+		return resp, err
+	}
+
+	fields["status"] = resp.StatusCode
+
+	for _, header := range correlationHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			fields["request_id"] = id
+			break
+		}
+	}
+
+	tflog.Debug(ctx, "ECK API request", fields)
+
+	return resp, nil
+}
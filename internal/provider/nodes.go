@@ -0,0 +1,233 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// controlPlaneRoleLabel is the label Kubernetes sets on control plane nodes.
+const controlPlaneRoleLabel = "node-role.kubernetes.io/control-plane"
+
+// node is the subset of a Kubernetes Node used by the provider.
+type node struct {
+	Metadata struct {
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels"`
+	} `json:"metadata"`
+	Status struct {
+		Conditions []struct {
+			Type   string `json:"type"`
+			Status string `json:"status"`
+		} `json:"conditions"`
+		Addresses []struct {
+			Type    string `json:"type"`
+			Address string `json:"address"`
+		} `json:"addresses"`
+		NodeInfo struct {
+			KubeletVersion string `json:"kubeletVersion"`
+		} `json:"nodeInfo"`
+	} `json:"status"`
+}
+
+// nodeList is the subset of a Kubernetes NodeList used by the provider.
+type nodeList struct {
+	Items []node `json:"items"`
+}
+
+// phase returns the readiness of a node as reported by kubectl, i.e. Ready,
+// NotReady, or Unknown if the kubelet has stopped reporting.
+func (n node) phase() string {
+	for _, condition := range n.Status.Conditions {
+		if condition.Type != "Ready" {
+			continue
+		}
+
+		switch condition.Status {
+		case "True":
+			return "Ready"
+		case "False":
+			return "NotReady"
+		}
+	}
+
+	return "Unknown"
+}
+
+// controlPlane reports whether a node is part of the control plane.
+func (n node) controlPlane() bool {
+	_, ok := n.Metadata.Labels[controlPlaneRoleLabel]
+	return ok
+}
+
+// kubernetesClient makes requests to the Kubernetes API of a cluster using
+// the credentials from its kubeconfig.
+type kubernetesClient struct {
+	client *http.Client
+	server string
+	token  string
+}
+
+func newKubernetesClient(kubeconfig string) (*kubernetesClient, error) {
+	decoded, err := decodeKubeconfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if decoded.Host.IsNull() {
+		return nil, errors.New("kubeconfig has no server")
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if !decoded.ClusterCACertificate.IsNull() {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(decoded.ClusterCACertificate.ValueString())) {
+			return nil, errors.New("kubeconfig CA certificate is not PEM encoded")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if !decoded.ClientCertificate.IsNull() && !decoded.ClientKey.IsNull() {
+		certificate, err := tls.X509KeyPair([]byte(decoded.ClientCertificate.ValueString()), []byte(decoded.ClientKey.ValueString()))
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &kubernetesClient{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   30 * time.Second,
+		},
+		server: decoded.Host.ValueString(),
+		token:  decoded.Token.ValueString(),
+	}, nil
+}
+
+// listNodes returns the nodes of the cluster.
+func (c *kubernetesClient) listNodes(ctx context.Context) ([]node, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.server+"/api/v1/nodes", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer drainBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response from Kubernetes API: %s", resp.Status)
+	}
+
+	var nodes nodeList
+	if err := json.NewDecoder(resp.Body).Decode(&nodes); err != nil {
+		return nil, err
+	}
+
+	return nodes.Items, nil
+}
+
+// readyNodes returns the number of nodes which are Ready.
+func (c *kubernetesClient) readyNodes(ctx context.Context) (int, error) {
+	nodes, err := c.listNodes(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	ready := 0
+
+	for _, node := range nodes {
+		if node.phase() == "Ready" {
+			ready++
+		}
+	}
+
+	return ready, nil
+}
+
+// expectedNodes returns the number of nodes a cluster should have once all
+// machines have joined.  Autoscaled pools are only expected to reach their
+// minimum size.
+func expectedNodes(m clusterModel) int {
+	expected := 0
+
+	if m.ControlPlane != nil {
+		expected += int(m.ControlPlane.Replicas.ValueInt64())
+	}
+
+	for _, pool := range m.WorkloadNodePools {
+		if pool.Autoscaling != nil {
+			expected += int(pool.Autoscaling.MinimumReplicas.ValueInt64())
+			continue
+		}
+
+		expected += int(pool.Replicas.ValueInt64())
+	}
+
+	return expected
+}
+
+// waitForNodesToBeReady polls the Kubernetes API of a cluster until at least
+// the expected number of nodes are Ready.  Errors from the Kubernetes API are
+// retried until the timeout, as the API may not be reachable immediately after
+// the cluster is provisioned.
+func waitForNodesToBeReady(ctx context.Context, kubeconfig string, expected int, poll pollConfig, timeout time.Duration) error {
+	client, err := newKubernetesClient(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.After(timeout)
+	schedule := poll.schedule()
+
+	var lastErr error
+
+	for {
+		ready, err := client.readyNodes(ctx)
+		if err == nil && ready >= expected {
+			return nil
+		}
+
+		lastErr = err
+
+		tflog.Debug(ctx, "Waiting for cluster nodes to be ready", map[string]any{
+			"ready":    ready,
+			"expected": expected,
+		})
+
+		timer := time.NewTimer(schedule.Next())
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("operation was canceled")
+		case <-deadline:
+			timer.Stop()
+			if lastErr != nil {
+				return fmt.Errorf("timed out after %s waiting for %d nodes to be ready: %w", timeout, expected, lastErr)
+			}
+			return fmt.Errorf("timed out after %s waiting for %d nodes to be ready, %d are ready", timeout, expected, ready)
+		case <-timer.C:
+		}
+	}
+}
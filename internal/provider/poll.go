@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"math/rand"
+	"time"
+)
+
+// pollConfig controls how often a cluster is polled while waiting for it to
+// be provisioned.
+type pollConfig struct {
+	// IntervalMin is the delay before the first poll, doubled after each poll.
+	IntervalMin time.Duration
+	// IntervalMax caps the delay between polls.
+	IntervalMax time.Duration
+}
+
+var defaultPollConfig = pollConfig{
+	IntervalMin: 5 * time.Second,
+	IntervalMax: 60 * time.Second,
+}
+
+// fixedPollConfig polls at a constant interval.
+func fixedPollConfig(interval time.Duration) pollConfig {
+	return pollConfig{
+		IntervalMin: interval,
+		IntervalMax: interval,
+	}
+}
+
+// pollSchedule returns the delays between successive polls.
+type pollSchedule struct {
+	config pollConfig
+	next   time.Duration
+}
+
+func (c pollConfig) schedule() *pollSchedule {
+	return &pollSchedule{
+		config: c,
+		next:   c.IntervalMin,
+	}
+}
+
+// Next returns the delay before the next poll.  Delays which back off vary by
+// up to 10% either way, so that clusters created together do not poll in
+// lockstep.
+func (s *pollSchedule) Next() time.Duration {
+	delay := s.next
+
+	s.next = min(s.next*2, s.config.IntervalMax)
+
+	if s.config.IntervalMin == s.config.IntervalMax {
+		return delay
+	}
+
+	return delay - delay/10 + time.Duration(rand.Int63n(int64(delay/5)+1)) //nolint:gosec
+}
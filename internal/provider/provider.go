@@ -2,21 +2,30 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 
-	"github.com/eschercloudai/eckctl/pkg/auth"
+	"github.com/eschercloudai/eckctl/pkg/generated"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &eckProvider{}
+	_ provider.Provider              = &eckProvider{}
+	_ provider.ProviderWithFunctions = &eckProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -37,10 +46,39 @@ type eckProvider struct {
 }
 
 type eckProviderModel struct {
-	Host     types.String `tfsdk:"host"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
-	Project  types.String `tfsdk:"project"`
+	Host            types.String  `tfsdk:"host"`
+	Region          types.String  `tfsdk:"region"`
+	Username        types.String  `tfsdk:"username"`
+	Password        types.String  `tfsdk:"password"`
+	PasswordFile    types.String  `tfsdk:"password_file"`
+	Project         types.String  `tfsdk:"project"`
+	Token           types.String  `tfsdk:"token"`
+	TokenFile       types.String  `tfsdk:"token_file"`
+	Insecure        types.Bool    `tfsdk:"insecure"`
+	CACert          types.String  `tfsdk:"ca_cert"`
+	CACertFile      types.String  `tfsdk:"ca_cert_file"`
+	ClientCert      types.String  `tfsdk:"client_cert"`
+	ClientCertFile  types.String  `tfsdk:"client_cert_file"`
+	ClientKey       types.String  `tfsdk:"client_key"`
+	ClientKeyFile   types.String  `tfsdk:"client_key_file"`
+	RequestTimeout  types.String  `tfsdk:"request_timeout"`
+	MaxRetries      types.Int64   `tfsdk:"max_retries"`
+	RetryWaitMin    types.String  `tfsdk:"retry_wait_min"`
+	RetryWaitMax    types.String  `tfsdk:"retry_wait_max"`
+	RateLimit       types.Float64 `tfsdk:"rate_limit"`
+	RateLimitBurst  types.Int64   `tfsdk:"rate_limit_burst"`
+	PollIntervalMin types.String  `tfsdk:"poll_interval_min"`
+	PollIntervalMax types.String  `tfsdk:"poll_interval_max"`
+	EckctlConfig    types.String  `tfsdk:"eckctl_config"`
+	Headers         types.Map     `tfsdk:"headers"`
+}
+
+// providerData is passed from the provider to resources and data sources.
+type providerData struct {
+	client *generated.ClientWithResponses
+	// poll controls how clusters are polled while waiting for them to be
+	// provisioned.
+	poll pollConfig
 }
 
 // Metadata returns the provider type name.
@@ -54,8 +92,13 @@ func (p *eckProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Description: "URL for the ECK API.  Can also be supplied as the environment variable `ECK_HOST`.",
+				Description: "URL for the ECK API.  Overrides the endpoint selected by `region`, for custom deployments.  Can also be supplied as the environment variable `ECK_HOST`.",
+				Optional:    true,
+			},
+			"region": schema.StringAttribute{
+				Description: "EscherCloud region, e.g. `nl1`, whose ECK API endpoint is used when `host` is not set.  Can also be supplied as the environment variable `ECK_REGION`, which is ignored when `ECK_HOST` is set.",
 				Optional:    true,
+				Validators:  dnsLabelValidators(),
 			},
 			"username": schema.StringAttribute{
 				Description: "Username for the ECK API.  Can also be supplied as the environment variable `ECK_USERNAME`.",
@@ -66,11 +109,142 @@ func (p *eckProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"password_file": schema.StringAttribute{
+				Description: "Path to a file containing the password for the ECK API, e.g. a secret mounted by a CI system.  A trailing newline is ignored.  Can also be supplied as the environment variable `ECK_PASSWORD_FILE`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("password")),
+				},
+			},
 			"project": schema.StringAttribute{
 				Description: "OpenStack Project UUID for the ECK API.  Can also be supplied as the environment variable `ECK_PROJECT`.",
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"token": schema.StringAttribute{
+				Description: "Pre-issued, project scoped access token for the ECK API, for example one obtained with `eckctl`.  When set, `username`, `password` and `project` are not required.  Can also be supplied as the environment variable `ECK_TOKEN`.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"token_file": schema.StringAttribute{
+				Description: "Path to a file containing a pre-issued access token for the ECK API, as for `token`.  A trailing newline is ignored.  Can also be supplied as the environment variable `ECK_TOKEN_FILE`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token")),
+				},
+			},
+			"insecure": schema.BoolAttribute{
+				Description: "Skip verification of the ECK API server certificate.  Only use this with test environments.  Can also be supplied as the environment variable `ECK_INSECURE`.",
+				Optional:    true,
+			},
+			"ca_cert": schema.StringAttribute{
+				Description: "PEM encoded CA certificates to trust when verifying the ECK API server certificate, in addition to the system certificate pool.  Can also be supplied as the environment variable `ECK_CA_CERT`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a file of PEM encoded CA certificates to trust when verifying the ECK API server certificate, in addition to the system certificate pool.  Can also be supplied as the environment variable `ECK_CA_CERT_FILE`.",
+				Optional:    true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description: "PEM encoded client certificate to present to the ECK API, for endpoints fronted by a gateway requiring mutual TLS.  Requires `client_key` or `client_key_file`.  Can also be supplied as the environment variable `ECK_CLIENT_CERT`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_cert_file")),
+				},
+			},
+			"client_cert_file": schema.StringAttribute{
+				Description: "Path to a file containing a PEM encoded client certificate to present to the ECK API.  Can also be supplied as the environment variable `ECK_CLIENT_CERT_FILE`.",
+				Optional:    true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM encoded private key of `client_cert`.  Can also be supplied as the environment variable `ECK_CLIENT_KEY`.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_key_file")),
+				},
+			},
+			"client_key_file": schema.StringAttribute{
+				Description: "Path to a file containing the PEM encoded private key of the client certificate.  Can also be supplied as the environment variable `ECK_CLIENT_KEY_FILE`.",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Maximum time to wait for each ECK API call, including any retries, e.g. `90s`.  Defaults to `2m`.  Can also be supplied as the environment variable `ECK_REQUEST_TIMEOUT`.",
+				Optional:    true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Number of times to retry ECK API requests which fail with a network error or transient HTTP status.  Creates are only retried when the API cannot have received them, or asks for them to be retried, so they are never made twice.  Set to `0` to disable retries.  Defaults to `4`.  Can also be supplied as the environment variable `ECK_MAX_RETRIES`.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_min": schema.StringAttribute{
+				Description: "Time to wait before the first retry, doubled on each subsequent attempt, e.g. `500ms`.  Defaults to `1s`.  Can also be supplied as the environment variable `ECK_RETRY_WAIT_MIN`.",
+				Optional:    true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
+			"retry_wait_max": schema.StringAttribute{
+				Description: "Maximum time to wait between retries.  Defaults to `30s`.  Can also be supplied as the environment variable `ECK_RETRY_WAIT_MAX`.",
+				Optional:    true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
+			"rate_limit": schema.Float64Attribute{
+				Description: "Maximum sustained number of ECK API requests per second, shared by all resources and data sources using the provider.  Set to `0` to disable rate limiting.  Defaults to `10`.  Can also be supplied as the environment variable `ECK_RATE_LIMIT`.",
+				Optional:    true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"rate_limit_burst": schema.Int64Attribute{
+				Description: "Number of ECK API requests which may be made at once before `rate_limit` applies.  Defaults to `20`.  Can also be supplied as the environment variable `ECK_RATE_LIMIT_BURST`.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"poll_interval_min": schema.StringAttribute{
+				Description: "Time to wait before first polling the status of a cluster which resources are waiting for, doubled after each poll, e.g. `10s`.  " +
+					"Resources which set `wait_interval` poll at that fixed interval instead.  Defaults to `5s`.  Can also be supplied as the environment variable `ECK_POLL_INTERVAL_MIN`.",
+				Optional: true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
+			"headers": schema.MapAttribute{
+				Description: "Additional HTTP headers to send with every ECK API request, keyed by name, for deployments behind gateways which require e.g. an API key or tenant header.  " +
+					"`Authorization` and `User-Agent` are set by the provider and cannot be overridden.  " +
+					"Can also be supplied as the environment variable `ECK_HEADERS`, as comma separated `Name=value` pairs.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(headerNameValidators()...),
+				},
+			},
+			"eckctl_config": schema.StringAttribute{
+				Description: "Path to an `eckctl` configuration file, usually `~/.eckctl.yaml`, to read the ECK API `url`, `username`, `password`, `project` and `insecure` settings from.  " +
+					"Settings in the provider configuration or its environment variables take precedence.  eckctl does not cache tokens, so the provider exchanges the credentials for a token itself.  " +
+					"Can also be supplied as the environment variable `ECK_ECKCTL_CONFIG`.",
+				Optional: true,
+			},
+			"poll_interval_max": schema.StringAttribute{
+				Description: "Maximum time to wait between polls of the status of a cluster.  Defaults to `60s`.  Can also be supplied as the environment variable `ECK_POLL_INTERVAL_MAX`.",
+				Optional:    true,
+				Validators: []validator.String{
+					validDuration(),
+				},
+			},
 		},
 	}
 }
@@ -86,14 +260,6 @@ func (p *eckProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
-	ctx = tflog.SetField(ctx, "eck_host", config.Host)
-	ctx = tflog.SetField(ctx, "eck_username", config.Username)
-	ctx = tflog.SetField(ctx, "eck_password", config.Password)
-	ctx = tflog.SetField(ctx, "eck_project", config.Project)
-	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "eck_password")
-
-	tflog.Debug(ctx, "Creating ECK client")
-
 	if config.Host.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("host"),
@@ -130,6 +296,24 @@ func (p *eckProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		)
 	}
 
+	if config.Token.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Unknown ECK API Token",
+			"The provider cannot create the ECK API client as there is an unknown configuration value for the ECK API token. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the ECK_TOKEN environment variable.",
+		)
+	}
+
+	if config.Headers.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("headers"),
+			"Unknown ECK API Headers",
+			"The provider cannot create the ECK API client as there is an unknown configuration value for the ECK API headers. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the ECK_HEADERS environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -141,6 +325,7 @@ func (p *eckProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	username := os.Getenv("ECK_USERNAME")
 	password := os.Getenv("ECK_PASSWORD")
 	project := os.Getenv("ECK_PROJECT")
+	token := os.Getenv("ECK_TOKEN")
 
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
@@ -158,6 +343,110 @@ func (p *eckProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		project = config.Project.ValueString()
 	}
 
+	if !config.Token.IsNull() {
+		token = config.Token.ValueString()
+	}
+
+	// Secrets may also be read from files, with the same precedence as the
+	// values themselves.
+	var err error
+
+	password, err = secretFromFile(password, config.PasswordFile, "ECK_PASSWORD_FILE")
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password_file"),
+			"Unable to Read ECK API Password",
+			"The provider cannot read the password file: "+err.Error(),
+		)
+		return
+	}
+
+	token, err = secretFromFile(token, config.TokenFile, "ECK_TOKEN_FILE")
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token_file"),
+			"Unable to Read ECK API Token",
+			"The provider cannot read the token file: "+err.Error(),
+		)
+		return
+	}
+
+	// A configured region takes precedence over the ECK_HOST environment
+	// variable, but not over a configured host.  The ECK_REGION environment
+	// variable is only used when no host is set at all.
+	if config.Host.IsNull() && !config.Region.IsNull() {
+		host = regionEndpoint(config.Region.ValueString())
+	}
+
+	if region := os.Getenv("ECK_REGION"); host == "" && config.Region.IsNull() && region != "" {
+		if !dnsLabelPattern.MatchString(region) || len(region) > 63 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("region"),
+				"Invalid Environment Variable",
+				"Environment variable ECK_REGION must be an EscherCloud region such as `nl1`, got: "+region,
+			)
+			return
+		}
+
+		host = regionEndpoint(region)
+	}
+
+	resp.Diagnostics.Append(applyEnvironment(&config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An eckctl configuration file supplies anything not otherwise configured
+	// or supplied by environment variables.
+	eckctlConfigFile := os.Getenv("ECK_ECKCTL_CONFIG")
+
+	if !config.EckctlConfig.IsNull() {
+		eckctlConfigFile = config.EckctlConfig.ValueString()
+	}
+
+	if eckctlConfigFile != "" {
+		eckctl, err := readEckctlConfig(eckctlConfigFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("eckctl_config"),
+				"Unable to Read eckctl Configuration",
+				"The provider cannot read the eckctl configuration file: "+err.Error(),
+			)
+			return
+		}
+
+		if host == "" {
+			host = eckctl.URL
+		}
+
+		if username == "" {
+			username = eckctl.Username
+		}
+
+		if password == "" {
+			password = eckctl.Password
+		}
+
+		if project == "" {
+			project = eckctl.Project
+		}
+
+		if config.Insecure.IsNull() {
+			config.Insecure = types.BoolValue(eckctl.Insecure)
+		}
+	}
+
+	ctx = tflog.SetField(ctx, "eck_host", host)
+	ctx = tflog.SetField(ctx, "eck_username", username)
+	ctx = tflog.SetField(ctx, "eck_project", project)
+	for _, secret := range []string{password, token} {
+		if secret != "" {
+			ctx = tflog.MaskAllFieldValuesStrings(ctx, secret)
+		}
+	}
+
+	tflog.Debug(ctx, "Creating ECK client")
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -166,48 +455,216 @@ func (p *eckProvider) Configure(ctx context.Context, req provider.ConfigureReque
 			path.Root("host"),
 			"Missing ECK API Host",
 			"The provider cannot create the ECK API client as there is a missing or empty value for the ECK API host. "+
-				"Set the host value in the configuration or use the ECK_HOST environment variable. "+
+				"Set the host or region value in the configuration, use the ECK_HOST or ECK_REGION environment variable, or set url in the eckctl configuration file. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
-	if username == "" {
+	// A pre-issued token replaces the username and password exchange.
+	if token == "" {
+		if username == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("username"),
+				"Missing ECK API Username",
+				"The provider cannot create the ECK API client as there is a missing or empty value for the ECK API username. "+
+					"Set the username value in the configuration or use the ECK_USERNAME environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
+
+		if password == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password"),
+				"Missing ECK API Password",
+				"The provider cannot create the ECK API client as there is a missing or empty value for the ECK API password. "+
+					"Set the password value in the configuration or use the ECK_PASSWORD environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
+
+		if project == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("project"),
+				"Missing ECK API Project",
+				"The provider cannot create the ECK API client as there is a missing or empty value for the ECK API project. "+
+					"Set the project value in the configuration or use the ECK_PROJECT environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	headers := map[string]string{}
+	if !config.Headers.IsNull() {
+		resp.Diagnostics.Append(config.Headers.ElementsAs(ctx, &headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	cfg := clientConfig{
+		insecure:  config.Insecure.ValueBool(),
+		timeout:   defaultRequestTimeout,
+		retry:     defaultRetryConfig,
+		rateLimit: defaultRateLimitConfig,
+		userAgent: fmt.Sprintf("terraform-provider-eck/%s Terraform/%s", p.version, req.TerraformVersion),
+		headers:   headers,
+	}
+
+	// Durations have already been checked by the schema validators.
+	if !config.RequestTimeout.IsNull() {
+		cfg.timeout, _ = time.ParseDuration(config.RequestTimeout.ValueString())
+	}
+
+	if !config.MaxRetries.IsNull() {
+		cfg.retry.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	if !config.RetryWaitMin.IsNull() {
+		cfg.retry.WaitMin, _ = time.ParseDuration(config.RetryWaitMin.ValueString())
+	}
+
+	if !config.RetryWaitMax.IsNull() {
+		cfg.retry.WaitMax, _ = time.ParseDuration(config.RetryWaitMax.ValueString())
+	}
+
+	if !config.RateLimit.IsNull() {
+		cfg.rateLimit.Rate = config.RateLimit.ValueFloat64()
+	}
+
+	if !config.RateLimitBurst.IsNull() {
+		cfg.rateLimit.Burst = int(config.RateLimitBurst.ValueInt64())
+	}
+
+	if cfg.retry.WaitMin > cfg.retry.WaitMax {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("username"),
-			"Missing ECK API Username",
-			"The provider cannot create the ECK API client as there is a missing or empty value for the ECK API username. "+
-				"Set the username value in the configuration or use the ECK_USERNAME environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+			path.Root("retry_wait_min"),
+			"Invalid ECK API Retry Configuration",
+			"The provider cannot create the ECK API client as retry_wait_min ("+cfg.retry.WaitMin.String()+
+				") is greater than retry_wait_max ("+cfg.retry.WaitMax.String()+").",
 		)
+		return
 	}
 
-	if password == "" {
+	poll := defaultPollConfig
+
+	if !config.PollIntervalMin.IsNull() {
+		poll.IntervalMin, _ = time.ParseDuration(config.PollIntervalMin.ValueString())
+	}
+
+	if !config.PollIntervalMax.IsNull() {
+		poll.IntervalMax, _ = time.ParseDuration(config.PollIntervalMax.ValueString())
+	}
+
+	if poll.IntervalMin > poll.IntervalMax {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("password"),
-			"Missing ECK API Password",
-			"The provider cannot create the ECK API client as there is a missing or empty value for the ECK API password. "+
-				"Set the password value in the configuration or use the ECK_PASSWORD environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+			path.Root("poll_interval_min"),
+			"Invalid Polling Configuration",
+			"The provider cannot be configured as poll_interval_min ("+poll.IntervalMin.String()+
+				") is greater than poll_interval_max ("+poll.IntervalMax.String()+").",
 		)
+		return
+	}
+
+	if !config.CACert.IsNull() {
+		cfg.caCert = []byte(config.CACert.ValueString())
+	}
+
+	if !config.CACertFile.IsNull() {
+		caCert, err := os.ReadFile(config.CACertFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Unable to Read ECK API CA Certificates",
+				"The provider cannot read the CA certificates file: "+err.Error(),
+			)
+			return
+		}
+
+		cfg.caCert = caCert
+	}
+
+	if !config.ClientCert.IsNull() {
+		cfg.clientCert = []byte(config.ClientCert.ValueString())
+	}
+
+	if !config.ClientCertFile.IsNull() {
+		clientCert, err := os.ReadFile(config.ClientCertFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("client_cert_file"),
+				"Unable to Read ECK API Client Certificate",
+				"The provider cannot read the client certificate file: "+err.Error(),
+			)
+			return
+		}
+
+		cfg.clientCert = clientCert
+	}
+
+	if !config.ClientKey.IsNull() {
+		cfg.clientKey = []byte(config.ClientKey.ValueString())
+	}
+
+	if !config.ClientKeyFile.IsNull() {
+		clientKey, err := os.ReadFile(config.ClientKeyFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("client_key_file"),
+				"Unable to Read ECK API Client Key",
+				"The provider cannot read the client key file: "+err.Error(),
+			)
+			return
+		}
+
+		cfg.clientKey = clientKey
 	}
 
-	if project == "" {
+	if (len(cfg.clientCert) == 0) != (len(cfg.clientKey) == 0) {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("project"),
-			"Missing ECK API Project",
-			"The provider cannot create the ECK API client as there is a missing or empty value for the ECK API project. "+
-				"Set the project value in the configuration or use the ECK_PROJECT environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+			path.Root("client_cert"),
+			"Incomplete ECK API Client Certificate",
+			"The provider cannot use a client certificate without its private key, or a private key without its certificate. "+
+				"Set both client_cert or client_cert_file and client_key or client_key_file.",
 		)
+		return
 	}
 
-	if resp.Diagnostics.HasError() {
+	httpClient, err := newHTTPClient(cfg)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create ECK API Client",
+			"An unexpected error occurred when creating the ECK API client. "+
+				"If the error is not clear, please contact the provider developers.\n\n"+
+				"ECK Client Error: "+err.Error(),
+		)
 		return
 	}
 
-	// Create a new ECK client using the configuration values
-	token, err := auth.GetToken(host, username, password, project, false)
-	client, _ := auth.NewClient(host, token, false)
+	// Create a new ECK client using the configuration values.  Tokens obtained
+	// with a username and password are refreshed when they expire.
+	var authenticate func(ctx context.Context) (string, error)
+	if token == "" {
+		authenticate = func(ctx context.Context) (string, error) {
+			return getToken(ctx, httpClient, cfg.userAgent, cfg.headers, host, username, password, project)
+		}
+
+		token, err = authenticate(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Create ECK API Client",
+				"An unexpected error occurred when creating the ECK API client. "+
+					"If the error is not clear, please contact the provider developers.\n\n"+
+					"ECK Client Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	client, err := newClient(host, httpClient, newTokenSource(token, authenticate), cfg.userAgent, cfg.headers)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create ECK API Client",
@@ -220,8 +677,12 @@ func (p *eckProvider) Configure(ctx context.Context, req provider.ConfigureReque
 
 	// Make the ECK client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	data := &providerData{
+		client: client,
+		poll:   poll,
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
 
 	tflog.Info(ctx, "Configured ECK client", map[string]any{"success": true})
 
@@ -232,7 +693,11 @@ func (p *eckProvider) DataSources(_ context.Context) []func() datasource.DataSou
 	return []func() datasource.DataSource{
 		NewControlPlaneDataSource,
 		NewClusterDataSource,
+		NewClusterNodesDataSource,
 		NewKubeconfigDataSource,
+		NewImageDataSource,
+		NewFlavorDataSource,
+		NewCompatibilityMatrixDataSource,
 	}
 }
 
@@ -243,3 +708,12 @@ func (p *eckProvider) Resources(_ context.Context) []func() resource.Resource {
 		NewClusterResource,
 	}
 }
+
+// Functions defines the functions implemented in the provider.
+func (p *eckProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewDecodeKubeconfigFunction,
+		NewCIDRSubnetsFunction,
+		NewVersionAtLeastFunction,
+	}
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitConfig controls how many ECK API requests a provider instance may
+// make.
+type rateLimitConfig struct {
+	// Rate is the sustained number of requests per second.  Zero disables rate
+	// limiting.
+	Rate float64
+	// Burst is the number of requests which may be made at once.
+	Burst int
+}
+
+var defaultRateLimitConfig = rateLimitConfig{
+	Rate:  10,
+	Burst: 20,
+}
+
+// rateLimitTransport is an http.RoundTripper which delays requests so that
+// they do not exceed a rate limit.  Every attempt made by the retrying
+// transport counts towards the limit.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func newRateLimitTransport(next http.RoundTripper, config rateLimitConfig) http.RoundTripper {
+	if config.Rate <= 0 {
+		return next
+	}
+
+	return &rateLimitTransport{
+		next:    next,
+		limiter: rate.NewLimiter(rate.Limit(config.Rate), config.Burst),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,363 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// reference is an attribute which names an object that must exist in the ECK
+// API, such as an image or flavor.
+type reference struct {
+	path  path.Path
+	value types.String
+}
+
+// listImageNames returns the names of the images available to clusters.
+func listImageNames(ctx context.Context, catalog *clusterCatalog) (map[string]bool, error) {
+	images, err := catalog.listImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for _, image := range images {
+		names[image.Name] = true
+	}
+
+	return names, nil
+}
+
+// listFlavorNames returns the names of the flavors available to clusters.
+func listFlavorNames(ctx context.Context, catalog *clusterCatalog) (map[string]bool, error) {
+	flavors, err := catalog.listFlavors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for _, flavor := range flavors {
+		names[flavor.Name] = true
+	}
+
+	return names, nil
+}
+
+// listClusterBundleNames returns the names of the cluster application bundles.
+func listClusterBundleNames(ctx context.Context, catalog *clusterCatalog) (map[string]bool, error) {
+	bundles, err := catalog.listClusterBundles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for _, bundle := range bundles {
+		names[bundle.Name] = true
+	}
+
+	return names, nil
+}
+
+// listKeyPairNames returns the names of the SSH key pairs of the project.
+func listKeyPairNames(ctx context.Context, catalog *clusterCatalog) (map[string]bool, error) {
+	keyPairs, err := catalog.listKeyPairs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for _, keyPair := range keyPairs {
+		names[keyPair.Name] = true
+	}
+
+	return names, nil
+}
+
+// sortedNames returns the names in a set in a stable order for diagnostics.
+func sortedNames(names map[string]bool) []string {
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+// newReferences returns the references whose values are known and not already
+// used in the current state, so clusters using an image, flavor or bundle
+// which has since been retired can still be updated.
+func newReferences(references []reference, current []types.String) []reference {
+	existing := map[string]bool{}
+	for _, value := range current {
+		existing[value.ValueString()] = true
+	}
+
+	var result []reference
+
+	for _, ref := range references {
+		if ref.value.IsNull() || ref.value.IsUnknown() || existing[ref.value.ValueString()] {
+			continue
+		}
+
+		result = append(result, ref)
+	}
+
+	return result
+}
+
+// checkReferences verifies that the images, flavors, application bundle and
+// SSH key pair referenced by a planned cluster exist, so that mistakes are
+// reported at plan time rather than after the API has accepted part of a
+// change.
+func checkReferences(ctx context.Context, catalog *clusterCatalog, plan clusterModel, state *clusterModel, resp *resource.ModifyPlanResponse) {
+	var imageRefs, flavorRefs []reference
+
+	if plan.ControlPlane != nil {
+		imageRefs = append(imageRefs, reference{path.Root("controlplane").AtName("image"), plan.ControlPlane.Image})
+		flavorRefs = append(flavorRefs, reference{path.Root("controlplane").AtName("flavor"), plan.ControlPlane.Flavor})
+	}
+
+	for i, pool := range plan.WorkloadNodePools {
+		poolPath := path.Root("workloadnodepools").AtListIndex(i)
+		imageRefs = append(imageRefs, reference{poolPath.AtName("image"), pool.Image})
+		flavorRefs = append(flavorRefs, reference{poolPath.AtName("flavor"), pool.Flavor})
+	}
+
+	var keyPairRefs []reference
+	if plan.ClusterOpenstack != nil {
+		keyPairRefs = append(keyPairRefs, reference{path.Root("clusteropenstack").AtName("sshkey"), plan.ClusterOpenstack.SshKeyName})
+	}
+
+	var currentImages, currentFlavors, currentBundles, currentKeyPairs []types.String
+
+	if state != nil {
+		if state.ControlPlane != nil {
+			currentImages = append(currentImages, state.ControlPlane.Image)
+			currentFlavors = append(currentFlavors, state.ControlPlane.Flavor)
+		}
+
+		for _, pool := range state.WorkloadNodePools {
+			currentImages = append(currentImages, pool.Image)
+			currentFlavors = append(currentFlavors, pool.Flavor)
+		}
+
+		currentBundles = append(currentBundles, state.ApplicationBundle)
+
+		if state.ClusterOpenstack != nil {
+			currentKeyPairs = append(currentKeyPairs, state.ClusterOpenstack.SshKeyName)
+		}
+	}
+
+	checks := []struct {
+		references []reference
+		kind       string
+		list       func(context.Context, *clusterCatalog) (map[string]bool, error)
+		hint       func(map[string]bool) string
+	}{
+		{
+			references: newReferences(imageRefs, currentImages),
+			kind:       "Image",
+			list:       listImageNames,
+			hint: func(_ map[string]bool) string {
+				return "Use the eck_image data source to look up the image for a Kubernetes version."
+			},
+		},
+		{
+			references: newReferences(flavorRefs, currentFlavors),
+			kind:       "Flavor",
+			list:       listFlavorNames,
+			hint: func(names map[string]bool) string {
+				return "Available flavors: " + strings.Join(sortedNames(names), ", ")
+			},
+		},
+		{
+			references: newReferences([]reference{{path.Root("applicationbundle"), plan.ApplicationBundle}}, currentBundles),
+			kind:       "Application Bundle",
+			list:       listClusterBundleNames,
+			hint: func(names map[string]bool) string {
+				return "Available bundles: " + strings.Join(sortedNames(names), ", ")
+			},
+		},
+		{
+			references: newReferences(keyPairRefs, currentKeyPairs),
+			kind:       "Key Pair",
+			list:       listKeyPairNames,
+			hint: func(names map[string]bool) string {
+				return "Available key pairs: " + strings.Join(sortedNames(names), ", ")
+			},
+		},
+	}
+
+	for _, check := range checks {
+		if len(check.references) == 0 {
+			continue
+		}
+
+		names, err := check.list(ctx, catalog)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Checking Cluster "+check.kind+"s",
+				"Could not list "+strings.ToLower(check.kind)+"s: "+err.Error(),
+			)
+			continue
+		}
+
+		for _, ref := range check.references {
+			if names[ref.value.ValueString()] {
+				continue
+			}
+
+			resp.Diagnostics.AddAttributeError(
+				ref.path,
+				check.kind+" Not Found",
+				fmt.Sprintf("%s %q does not exist.  %s", check.kind, ref.value.ValueString(), check.hint(names)),
+			)
+		}
+	}
+}
+
+// minControlPlaneCPUs and minControlPlaneMemory, in GiB, are the smallest
+// flavor which can run the Kubernetes control plane components and etcd.
+const (
+	minControlPlaneCPUs   = 2
+	minControlPlaneMemory = 4
+)
+
+// checkControlPlaneFlavor verifies that a new control plane flavor is large
+// enough to run the control plane, as the API accepts any flavor but the
+// cluster never becomes usable on a small one.  Flavors which do not exist are
+// reported by checkReferences, as are errors listing flavors.
+func checkControlPlaneFlavor(ctx context.Context, catalog *clusterCatalog, plan clusterModel, state *clusterModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if plan.ControlPlane == nil {
+		return diags
+	}
+
+	var current []types.String
+	if state != nil && state.ControlPlane != nil {
+		current = append(current, state.ControlPlane.Flavor)
+	}
+
+	refs := newReferences([]reference{{path.Root("controlplane").AtName("flavor"), plan.ControlPlane.Flavor}}, current)
+	if len(refs) == 0 {
+		return diags
+	}
+
+	flavors, err := catalog.listFlavors(ctx)
+	if err != nil {
+		return diags
+	}
+
+	for _, flavor := range flavors {
+		if flavor.Name != refs[0].value.ValueString() {
+			continue
+		}
+
+		if flavor.Cpus < minControlPlaneCPUs || flavor.Memory < minControlPlaneMemory {
+			diags.AddAttributeError(
+				refs[0].path,
+				"Control Plane Flavor Too Small",
+				fmt.Sprintf("Flavor %q has %d vCPUs and %d GiB of memory, but control plane nodes need at least %d vCPUs and %d GiB of memory.",
+					flavor.Name, flavor.Cpus, flavor.Memory, minControlPlaneCPUs, minControlPlaneMemory),
+			)
+		}
+	}
+
+	return diags
+}
+
+// imageVersion is a Kubernetes version and the image its machines boot from.
+type imageVersion struct {
+	path    path.Path
+	image   types.String
+	version types.String
+}
+
+// checkImageVersions verifies that the Kubernetes version of each new or
+// changed control plane and workload pool matches the version bundled with
+// its image, as the API accepts any combination but the machines never join
+// the cluster.  Images which do not exist are reported by checkReferences.
+func checkImageVersions(ctx context.Context, catalog *clusterCatalog, plan clusterModel, state *clusterModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Machines which already exist are left alone, so clusters whose versions
+	// were changed outside of Terraform can still be updated.
+	current := map[[2]string]bool{}
+	if state != nil {
+		if state.ControlPlane != nil {
+			current[[2]string{state.ControlPlane.Image.ValueString(), state.ControlPlane.Version.ValueString()}] = true
+		}
+
+		for _, pool := range state.WorkloadNodePools {
+			current[[2]string{pool.Image.ValueString(), pool.Version.ValueString()}] = true
+		}
+	}
+
+	var planned []imageVersion
+	if plan.ControlPlane != nil {
+		planned = append(planned, imageVersion{path.Root("controlplane").AtName("version"), plan.ControlPlane.Image, plan.ControlPlane.Version})
+	}
+
+	for i, pool := range plan.WorkloadNodePools {
+		planned = append(planned, imageVersion{path.Root("workloadnodepools").AtListIndex(i).AtName("version"), pool.Image, pool.Version})
+	}
+
+	var checks []imageVersion
+
+	for _, p := range planned {
+		if p.image.IsNull() || p.image.IsUnknown() || p.version.IsNull() || p.version.IsUnknown() {
+			continue
+		}
+
+		if current[[2]string{p.image.ValueString(), p.version.ValueString()}] {
+			continue
+		}
+
+		checks = append(checks, p)
+	}
+
+	if len(checks) == 0 {
+		return diags
+	}
+
+	// The error matches checkReferences, so it is only reported once.
+	images, err := catalog.listImages(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error Checking Cluster Images",
+			"Could not list images: "+err.Error(),
+		)
+		return diags
+	}
+
+	versions := map[string]string{}
+	for _, image := range images {
+		versions[image.Name] = image.Versions.Kubernetes
+	}
+
+	for _, check := range checks {
+		version, ok := versions[check.image.ValueString()]
+		if !ok || version == check.version.ValueString() {
+			continue
+		}
+
+		diags.AddAttributeError(
+			check.path,
+			"Kubernetes Version Does Not Match Image",
+			fmt.Sprintf("Kubernetes version %s does not match image %q, which bundles Kubernetes %s.  "+
+				"Set the version to %s, or use the eck_image data source or image_auto_select to choose an image for %s.",
+				check.version.ValueString(), check.image.ValueString(), version, version, check.version.ValueString()),
+		)
+	}
+
+	return diags
+}
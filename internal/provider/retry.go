@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// retryConfig controls how transient ECK API failures are retried.
+type retryConfig struct {
+	// MaxRetries is the number of additional attempts made after the first
+	// request fails.  Zero disables retries.
+	MaxRetries int
+	// WaitMin is the delay before the first retry, doubled on each attempt.
+	WaitMin time.Duration
+	// WaitMax caps the delay between attempts.
+	WaitMax time.Duration
+}
+
+var defaultRetryConfig = retryConfig{
+	MaxRetries: 4,
+	WaitMin:    1 * time.Second,
+	WaitMax:    30 * time.Second,
+}
+
+// retriesKey is the context key of a request retry counter.
+type retriesKey struct{}
+
+// withRetryCount returns a context which counts how many times requests made
+// with it are retried, so a caller can tell whether a request was sent more
+// than once.
+func withRetryCount(ctx context.Context) (context.Context, *atomic.Int32) {
+	retries := &atomic.Int32{}
+
+	return context.WithValue(ctx, retriesKey{}, retries), retries
+}
+
+// retryTransport is an http.RoundTripper which retries requests that fail with
+// a network error or a transient HTTP status, backing off exponentially
+// between attempts.  Requests which are not idempotent are only retried when
+// they cannot have been processed, so a create is never sent twice.
+type retryTransport struct {
+	next   http.RoundTripper
+	config retryConfig
+}
+
+func newRetryTransport(next http.RoundTripper, config retryConfig) *retryTransport {
+	return &retryTransport{
+		next:   next,
+		config: config,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			// The previous attempt consumed the body, so rewind it.
+			if req.GetBody == nil {
+				return nil, errors.New("unable to retry request with a non-rewindable body")
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(r)
+		if attempt >= t.config.MaxRetries || !isRetryable(req.Method, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		fields := map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+			drainBody(resp.Body)
+		}
+		tflog.Debug(ctx, "Retrying ECK API request", fields)
+
+		if retries, ok := ctx.Value(retriesKey{}).(*atomic.Int32); ok {
+			retries.Add(1)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns how long to wait before the next attempt, preferring any
+// Retry-After hint supplied by the server.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			wait := time.Duration(seconds) * time.Second
+			if wait > t.config.WaitMax {
+				return t.config.WaitMax
+			}
+			return wait
+		}
+	}
+
+	wait := t.config.WaitMin << attempt
+	if wait <= 0 || wait > t.config.WaitMax {
+		return t.config.WaitMax
+	}
+	return wait
+}
+
+// isRetryable reports whether a request outcome is likely to succeed if
+// attempted again.
+func isRetryable(method string, resp *http.Response, err error) bool {
+	if err != nil {
+		// An untrusted server certificate will not become trusted by retrying.
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) {
+			return false
+		}
+
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+
+		// A request which failed to connect was never sent.
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+
+		return isIdempotent(method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusServiceUnavailable:
+		// The server asked for the request to be retried later, so has not
+		// processed it.
+		if resp.Header.Get("Retry-After") != "" {
+			return true
+		}
+
+		return isIdempotent(method)
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+
+	return false
+}
+
+// isIdempotent reports whether sending a request more than once has the same
+// effect as sending it once, so it is safe to retry after any failure.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,342 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// roundTripperFunc adapts a function to an http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// testRetryConfig retries quickly so tests don't wait on the backoff.
+var testRetryConfig = retryConfig{
+	MaxRetries: 3,
+	WaitMin:    time.Millisecond,
+	WaitMax:    5 * time.Millisecond,
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	readErr := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	certErr := &tls.CertificateVerificationError{Err: errors.New("unknown authority")}
+
+	status := func(code int, retryAfter string) *http.Response {
+		resp := &http.Response{StatusCode: code, Header: http.Header{}}
+		if retryAfter != "" {
+			resp.Header.Set("Retry-After", retryAfter)
+		}
+
+		return resp
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		resp   *http.Response
+		err    error
+		want   bool
+	}{
+		{name: "GET dial error", method: http.MethodGet, err: dialErr, want: true},
+		{name: "GET read error", method: http.MethodGet, err: readErr, want: true},
+		{name: "PUT read error", method: http.MethodPut, err: readErr, want: true},
+		{name: "DELETE read error", method: http.MethodDelete, err: readErr, want: true},
+		{name: "POST dial error", method: http.MethodPost, err: dialErr, want: true},
+		{name: "POST read error", method: http.MethodPost, err: readErr, want: false},
+		{name: "PATCH read error", method: http.MethodPatch, err: readErr, want: false},
+		{name: "certificate error", method: http.MethodGet, err: &net.OpError{Op: "dial", Err: certErr}, want: false},
+		{name: "canceled", method: http.MethodGet, err: context.Canceled, want: false},
+		{name: "deadline exceeded", method: http.MethodGet, err: context.DeadlineExceeded, want: false},
+		{name: "GET 500", method: http.MethodGet, resp: status(http.StatusInternalServerError, ""), want: true},
+		{name: "GET 502", method: http.MethodGet, resp: status(http.StatusBadGateway, ""), want: true},
+		{name: "GET 504", method: http.MethodGet, resp: status(http.StatusGatewayTimeout, ""), want: true},
+		{name: "POST 500", method: http.MethodPost, resp: status(http.StatusInternalServerError, ""), want: false},
+		{name: "GET 429", method: http.MethodGet, resp: status(http.StatusTooManyRequests, ""), want: true},
+		{name: "POST 429", method: http.MethodPost, resp: status(http.StatusTooManyRequests, ""), want: false},
+		{name: "POST 429 with Retry-After", method: http.MethodPost, resp: status(http.StatusTooManyRequests, "5"), want: true},
+		{name: "POST 503", method: http.MethodPost, resp: status(http.StatusServiceUnavailable, ""), want: false},
+		{name: "POST 503 with Retry-After", method: http.MethodPost, resp: status(http.StatusServiceUnavailable, "5"), want: true},
+		{name: "GET 200", method: http.MethodGet, resp: status(http.StatusOK, ""), want: false},
+		{name: "GET 404", method: http.MethodGet, resp: status(http.StatusNotFound, ""), want: false},
+		{name: "PUT 409", method: http.MethodPut, resp: status(http.StatusConflict, ""), want: false},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isRetryable(test.method, test.resp, test.err); got != test.want {
+				t.Errorf("isRetryable() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	t.Parallel()
+
+	transport := newRetryTransport(http.DefaultTransport, defaultRetryConfig)
+
+	retryAfter := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+	}
+
+	tests := []struct {
+		name    string
+		attempt int
+		resp    *http.Response
+		want    time.Duration
+	}{
+		{name: "first attempt", attempt: 0, want: time.Second},
+		{name: "doubled", attempt: 2, want: 4 * time.Second},
+		{name: "capped", attempt: 5, want: 30 * time.Second},
+		{name: "overflow", attempt: 70, want: 30 * time.Second},
+		{name: "Retry-After", attempt: 0, resp: retryAfter("7"), want: 7 * time.Second},
+		{name: "Retry-After zero", attempt: 3, resp: retryAfter("0"), want: 0},
+		{name: "Retry-After capped", attempt: 0, resp: retryAfter("120"), want: 30 * time.Second},
+		{name: "Retry-After date", attempt: 1, resp: retryAfter("Wed, 21 Oct 2015 07:28:00 GMT"), want: 2 * time.Second},
+		{name: "Retry-After negative", attempt: 1, resp: retryAfter("-1"), want: 2 * time.Second},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := transport.backoff(test.attempt, test.resp); got != test.want {
+				t.Errorf("backoff() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		method   string
+		statuses []int
+		header   http.Header
+		want     int
+		requests int32
+	}{
+		{
+			name:     "success",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusOK},
+			want:     http.StatusOK,
+			requests: 1,
+		},
+		{
+			name:     "GET retried until success",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			want:     http.StatusOK,
+			requests: 3,
+		},
+		{
+			name:     "GET retries exhausted",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusBadGateway},
+			want:     http.StatusBadGateway,
+			requests: 4,
+		},
+		{
+			name:     "POST not retried",
+			method:   http.MethodPost,
+			statuses: []int{http.StatusBadGateway, http.StatusOK},
+			want:     http.StatusBadGateway,
+			requests: 1,
+		},
+		{
+			name:     "POST retried after Retry-After",
+			method:   http.MethodPost,
+			statuses: []int{http.StatusTooManyRequests, http.StatusCreated},
+			header:   http.Header{"Retry-After": []string{"0"}},
+			want:     http.StatusCreated,
+			requests: 2,
+		},
+		{
+			name:     "client error not retried",
+			method:   http.MethodPut,
+			statuses: []int{http.StatusBadRequest, http.StatusOK},
+			want:     http.StatusBadRequest,
+			requests: 1,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(requests.Add(1)) - 1
+				if n >= len(test.statuses) {
+					n = len(test.statuses) - 1
+				}
+
+				if body, _ := io.ReadAll(r.Body); r.Method == http.MethodPost && string(body) != `{"name":"test"}` {
+					t.Errorf("request %d body = %q", n, body)
+				}
+
+				for k, v := range test.header {
+					w.Header()[k] = v
+				}
+
+				w.WriteHeader(test.statuses[n])
+			}))
+			defer server.Close()
+
+			ctx, retries := withRetryCount(context.Background())
+
+			var body io.Reader
+			if test.method == http.MethodPost {
+				body = strings.NewReader(`{"name":"test"}`)
+			}
+
+			req, err := http.NewRequestWithContext(ctx, test.method, server.URL, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, testRetryConfig)}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != test.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, test.want)
+			}
+
+			if got := requests.Load(); got != test.requests {
+				t.Errorf("server received %d requests, want %d", got, test.requests)
+			}
+
+			if got := retries.Load(); got != test.requests-1 {
+				t.Errorf("retry count = %d, want %d", got, test.requests-1)
+			}
+		})
+	}
+}
+
+func TestRetryTransportDialError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		method   string
+		err      error
+		attempts int32
+	}{
+		{
+			name:     "POST dial error",
+			method:   http.MethodPost,
+			err:      &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED},
+			attempts: 2,
+		},
+		{
+			name:     "POST read error",
+			method:   http.MethodPost,
+			err:      &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+			attempts: 1,
+		},
+		{
+			name:     "GET read error",
+			method:   http.MethodGet,
+			err:      &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+			attempts: 2,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+
+			// Fail the first attempt, then succeed.
+			next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				if attempts.Add(1) == 1 {
+					return nil, test.err
+				}
+
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+			})
+
+			ctx, retries := withRetryCount(context.Background())
+
+			req, err := http.NewRequestWithContext(ctx, test.method, "http://eck.invalid", strings.NewReader("{}"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := newRetryTransport(next, testRetryConfig).RoundTrip(req)
+			if test.attempts == 1 {
+				if !errors.Is(err, test.err) {
+					t.Errorf("error = %v, want %v", err, test.err)
+				}
+			} else if err != nil || resp.StatusCode != http.StatusOK {
+				t.Errorf("RoundTrip() = %v, %v, want 200", resp, err)
+			}
+
+			if got := attempts.Load(); got != test.attempts {
+				t.Errorf("attempts = %d, want %d", got, test.attempts)
+			}
+
+			if got := retries.Load(); got != test.attempts-1 {
+				t.Errorf("retry count = %d, want %d", got, test.attempts-1)
+			}
+		})
+	}
+}
+
+func TestRetryTransportContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		cancel()
+
+		return &http.Response{StatusCode: http.StatusBadGateway, Body: http.NoBody}, nil
+	})
+
+	config := retryConfig{MaxRetries: 3, WaitMin: time.Minute, WaitMax: time.Minute}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://eck.invalid", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newRetryTransport(next, config).RoundTrip(req); !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want %v", err, context.Canceled)
+	}
+}
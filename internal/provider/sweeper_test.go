@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/eschercloudai/eckctl/pkg/generated"
+)
+
+// testAccPrefix prefixes the names of control planes and clusters created by
+// acceptance tests, so that sweepers only delete test resources.
+const testAccPrefix = "tf-acc-"
+
+// TestMain runs the sweepers when the -sweep flag is given, e.g.
+// `go test ./internal/provider -v -sweep=<region>`, and the tests otherwise.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("eck_cluster", &resource.Sweeper{
+		Name: "eck_cluster",
+		F:    sweepClusters,
+	})
+
+	// Clusters are swept first so that control planes are empty when they
+	// are deleted.
+	resource.AddTestSweepers("eck_controlplane", &resource.Sweeper{
+		Name:         "eck_controlplane",
+		Dependencies: []string{"eck_cluster"},
+		F:            sweepControlPlanes,
+	})
+}
+
+// sweeperClient creates an ECK API client from the same environment variables
+// as the provider.  The host defaults to the endpoint of the swept region.
+func sweeperClient(region string) (*generated.ClientWithResponses, error) {
+	ctx := context.Background()
+
+	host := os.Getenv("ECK_HOST")
+	if host == "" {
+		host = regionEndpoint(region)
+	}
+
+	insecure, _ := strconv.ParseBool(os.Getenv("ECK_INSECURE"))
+
+	httpClient, err := newHTTPClient(clientConfig{
+		insecure:  insecure,
+		timeout:   defaultRequestTimeout,
+		retry:     defaultRetryConfig,
+		rateLimit: defaultRateLimitConfig,
+		userAgent: "terraform-provider-eck/sweeper",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := newTokenSource(os.Getenv("ECK_TOKEN"), nil)
+
+	if tokens.Token() == "" {
+		username, password, project := os.Getenv("ECK_USERNAME"), os.Getenv("ECK_PASSWORD"), os.Getenv("ECK_PROJECT")
+		if username == "" || password == "" || project == "" {
+			return nil, fmt.Errorf("ECK_TOKEN, or ECK_USERNAME, ECK_PASSWORD and ECK_PROJECT, must be set")
+		}
+
+		authenticate := func(ctx context.Context) (string, error) {
+			return getToken(ctx, httpClient, "terraform-provider-eck/sweeper", nil, host, username, password, project)
+		}
+
+		token, err := authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = newTokenSource(token, authenticate)
+	}
+
+	return newClient(host, httpClient, tokens, "terraform-provider-eck/sweeper", nil)
+}
+
+// listControlPlaneNames returns the names of all control planes.
+func listControlPlaneNames(ctx context.Context, client *generated.ClientWithResponses) ([]string, error) {
+	r, err := client.GetApiV1ControlplanesWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.JSON200 == nil {
+		return nil, newAPIError(r.StatusCode(), r.Status(), r.Body)
+	}
+
+	var names []string
+	for _, controlPlane := range *r.JSON200 {
+		names = append(names, controlPlane.Name)
+	}
+
+	return names, nil
+}
+
+// sweepClusters deletes test clusters from every control plane, as tests may
+// create clusters in existing control planes.
+func sweepClusters(region string) error {
+	ctx := context.Background()
+
+	client, err := sweeperClient(region)
+	if err != nil {
+		return err
+	}
+
+	controlPlanes, err := listControlPlaneNames(ctx, client)
+	if err != nil {
+		return fmt.Errorf("listing control planes: %w", err)
+	}
+
+	for _, controlPlane := range controlPlanes {
+		clusters, err := activeClusterNames(ctx, client, controlPlane)
+		if err != nil {
+			return fmt.Errorf("listing clusters in control plane %s: %w", controlPlane, err)
+		}
+
+		for _, cluster := range clusters {
+			if !strings.HasPrefix(cluster, testAccPrefix) {
+				continue
+			}
+
+			r, err := client.DeleteApiV1ControlplanesControlPlaneNameClustersClusterNameWithResponse(ctx, controlPlane, cluster)
+			if err != nil {
+				return fmt.Errorf("deleting cluster %s/%s: %w", controlPlane, cluster, err)
+			}
+
+			if !isSuccess(r.StatusCode()) && r.StatusCode() != http.StatusNotFound {
+				return fmt.Errorf("deleting cluster %s/%s: %w", controlPlane, cluster, newAPIError(r.StatusCode(), r.Status(), r.Body))
+			}
+		}
+	}
+
+	return nil
+}
+
+// sweepControlPlanes deletes test control planes.
+func sweepControlPlanes(region string) error {
+	ctx := context.Background()
+
+	client, err := sweeperClient(region)
+	if err != nil {
+		return err
+	}
+
+	controlPlanes, err := listControlPlaneNames(ctx, client)
+	if err != nil {
+		return fmt.Errorf("listing control planes: %w", err)
+	}
+
+	for _, controlPlane := range controlPlanes {
+		if !strings.HasPrefix(controlPlane, testAccPrefix) {
+			continue
+		}
+
+		r, err := client.DeleteApiV1ControlplanesControlPlaneNameWithResponse(ctx, controlPlane)
+		if err != nil {
+			return fmt.Errorf("deleting control plane %s: %w", controlPlane, err)
+		}
+
+		if !isSuccess(r.StatusCode()) && r.StatusCode() != http.StatusNotFound {
+			return fmt.Errorf("deleting control plane %s: %w", controlPlane, newAPIError(r.StatusCode(), r.Status(), r.Body))
+		}
+	}
+
+	return nil
+}
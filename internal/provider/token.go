@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// tokenSource holds the access token used to authenticate with the ECK API.
+// It is shared by every request made by a provider instance, so is safe for
+// concurrent use.
+type tokenSource struct {
+	lock  sync.Mutex
+	token string
+	// authenticate obtains a new token.  It is nil when the token was
+	// pre-issued, in which case the token cannot be refreshed.
+	authenticate func(ctx context.Context) (string, error)
+}
+
+func newTokenSource(token string, authenticate func(ctx context.Context) (string, error)) *tokenSource {
+	return &tokenSource{
+		token:        token,
+		authenticate: authenticate,
+	}
+}
+
+// Token returns the current access token.
+func (s *tokenSource) Token() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.token
+}
+
+// refresh replaces a token rejected by the API.  If another request has
+// already replaced it, the new token is returned without re-authenticating.
+// Concurrent requests wait for the refresh holding the lock, so it is not
+// cancelled with the request which started it, and is bounded by the HTTP
+// client timeout instead.
+func (s *tokenSource) refresh(ctx context.Context, rejected string) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.token != rejected {
+		return s.token, nil
+	}
+
+	token, err := s.authenticate(context.WithoutCancel(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	s.token = token
+
+	return token, nil
+}
+
+// authTransport is an http.RoundTripper which authenticates requests with a
+// bearer token, re-authenticating and retrying once when the API rejects an
+// expired token.
+type authTransport struct {
+	next   http.RoundTripper
+	tokens *tokenSource
+}
+
+func newAuthTransport(next http.RoundTripper, tokens *tokenSource) *authTransport {
+	return &authTransport{
+		next:   next,
+		tokens: tokens,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token := t.tokens.Token()
+
+	resp, err := t.next.RoundTrip(withBearerToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || t.tokens.authenticate == nil {
+		return resp, err
+	}
+
+	// The previous attempt consumed the body, so it must be rewound.
+	hasBody := req.Body != nil && req.Body != http.NoBody
+	if hasBody && req.GetBody == nil {
+		return resp, nil
+	}
+
+	ctx := req.Context()
+
+	token, err = t.tokens.refresh(ctx, token)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to refresh ECK API token", map[string]any{"error": err.Error()})
+		return resp, nil
+	}
+
+	tflog.Debug(ctx, "Refreshed ECK API token", map[string]any{
+		"method": req.Method,
+		"url":    req.URL.String(),
+	})
+
+	drainBody(resp.Body)
+
+	r := withBearerToken(req, token)
+	if hasBody {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+
+	return t.next.RoundTrip(r)
+}
+
+// withBearerToken returns a copy of the request authenticated with the token,
+// as a RoundTripper must not modify the original request.
+func withBearerToken(req *http.Request, token string) *http.Request {
+	r := req.Clone(req.Context())
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	return r
+}
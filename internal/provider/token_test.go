@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testTokenServer is an ECK API which accepts a single valid token, rejecting
+// all others as expired.
+type testTokenServer struct {
+	*httptest.Server
+
+	lock  sync.Mutex
+	valid string
+}
+
+func newTestTokenServer(t *testing.T, valid string) *testTokenServer {
+	t.Helper()
+
+	s := &testTokenServer{valid: valid}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		valid := s.valid
+		s.lock.Unlock()
+
+		if r.Header.Get("Authorization") != "Bearer "+valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		// Echo the body so tests can check it is resent after a refresh.
+		_, _ = io.Copy(w, r.Body)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// issue makes a new token the only one accepted by the server.
+func (s *testTokenServer) issue(token string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.valid = token
+}
+
+func TestAuthTransportRefresh(t *testing.T) {
+	t.Parallel()
+
+	server := newTestTokenServer(t, "token-1")
+
+	var authentications atomic.Int32
+
+	tokens := newTokenSource("token-0", func(ctx context.Context) (string, error) {
+		token := fmt.Sprintf("token-%d", authentications.Add(1))
+
+		// Give the other requests time to be rejected and wait on the
+		// refresh.
+		time.Sleep(50 * time.Millisecond)
+
+		server.issue(token)
+
+		return token, nil
+	})
+
+	client := &http.Client{Transport: newAuthTransport(http.DefaultTransport, tokens)}
+
+	const requests = 20
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, requests)
+
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			body := fmt.Sprintf(`{"request":%d}`, i)
+
+			resp, err := client.Post(server.URL, "application/json", strings.NewReader(body))
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer resp.Body.Close()
+
+			got, err := io.ReadAll(resp.Body)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			if resp.StatusCode != http.StatusOK || string(got) != body {
+				errs <- fmt.Errorf("request %d: status %d, body %q", i, resp.StatusCode, got)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if got := authentications.Load(); got != 1 {
+		t.Errorf("authenticated %d times, want 1", got)
+	}
+
+	if got := tokens.Token(); got != "token-1" {
+		t.Errorf("token = %q, want %q", got, "token-1")
+	}
+}
+
+func TestAuthTransportPreIssuedToken(t *testing.T) {
+	t.Parallel()
+
+	server := newTestTokenServer(t, "token-1")
+
+	client := &http.Client{Transport: newAuthTransport(http.DefaultTransport, newTokenSource("token-0", nil))}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+}
+
+func TestAuthTransportRefreshFailure(t *testing.T) {
+	t.Parallel()
+
+	server := newTestTokenServer(t, "token-1")
+
+	tokens := newTokenSource("token-0", func(ctx context.Context) (string, error) {
+		return "", fmt.Errorf("invalid credentials")
+	})
+
+	client := &http.Client{Transport: newAuthTransport(http.DefaultTransport, tokens)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	// The original response is returned, so the caller reports the 401.
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	if got := tokens.Token(); got != "token-0" {
+		t.Errorf("token = %q, want %q", got, "token-0")
+	}
+}
+
+func TestTokenSourceRefreshNotCancelled(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	var authentications atomic.Int32
+
+	tokens := newTokenSource("token-0", func(ctx context.Context) (string, error) {
+		if authentications.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		return "token-1", nil
+	})
+
+	type result struct {
+		token string
+		err   error
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := make(chan result, 1)
+
+	go func() {
+		token, err := tokens.refresh(ctx, "token-0")
+		first <- result{token, err}
+	}()
+
+	<-started
+
+	// A second request waits for the refresh started by the first, which is
+	// then cancelled.
+	second := make(chan result, 1)
+
+	go func() {
+		token, err := tokens.refresh(context.Background(), "token-0")
+		second <- result{token, err}
+	}()
+
+	cancel()
+	close(release)
+
+	for name, ch := range map[string]chan result{"first": first, "second": second} {
+		if r := <-ch; r.err != nil || r.token != "token-1" {
+			t.Errorf("%s refresh = %q, %v, want %q", name, r.token, r.err, "token-1")
+		}
+	}
+
+	if got := authentications.Load(); got != 1 {
+		t.Errorf("authenticated %d times, want 1", got)
+	}
+}
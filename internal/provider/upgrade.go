@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+)
+
+// upgradeStep is a cluster specification applied, and waited for, as part of
+// a rolling upgrade.
+type upgradeStep struct {
+	description string
+	model       clusterModel
+}
+
+// poolUpgraded reports whether a workload pool runs a different Kubernetes
+// version or image in the plan.
+func poolUpgraded(current workloadNodePoolModel, planned workloadNodePoolModel) bool {
+	return !current.Version.Equal(planned.Version) || !current.Image.Equal(planned.Image)
+}
+
+// rollingUpgradeSteps splits an update into a sequence of specifications so
+// the control plane is upgraded before any workload pool, and each workload
+// pool is upgraded in turn.  All other changes are applied alongside the
+// control plane, except pool changes which are deferred to the final step.
+// Updates which do not change the Kubernetes version or images of existing
+// machines are applied in a single step.
+func rollingUpgradeSteps(ctx context.Context, state clusterModel, plan clusterModel) []upgradeStep {
+	current := plan
+	current.WorkloadNodePools = append([]workloadNodePoolModel(nil), state.WorkloadNodePools...)
+
+	var steps []upgradeStep
+
+	if state.ControlPlane != nil && plan.ControlPlane != nil &&
+		(!state.ControlPlane.Version.Equal(plan.ControlPlane.Version) || !state.ControlPlane.Image.Equal(plan.ControlPlane.Image)) {
+		steps = append(steps, upgradeStep{
+			description: "control plane",
+			model:       current,
+		})
+	}
+
+	for i, pool := range current.WorkloadNodePools {
+		for _, planned := range plan.WorkloadNodePools {
+			if !planned.Name.Equal(pool.Name) || !poolUpgraded(pool, planned) {
+				continue
+			}
+
+			current.WorkloadNodePools = append([]workloadNodePoolModel(nil), current.WorkloadNodePools...)
+			current.WorkloadNodePools[i] = planned
+
+			steps = append(steps, upgradeStep{
+				description: "workload pool " + planned.Name.ValueString(),
+				model:       current,
+			})
+		}
+	}
+
+	// Apply anything left over, such as added or removed pools, unless the
+	// last step already matches the plan.
+	if len(steps) == 0 || !reflect.DeepEqual(generateKubernetesCluster(ctx, current), generateKubernetesCluster(ctx, plan)) {
+		steps = append(steps, upgradeStep{
+			description: "remaining changes",
+			model:       plan,
+		})
+	}
+
+	return steps
+}
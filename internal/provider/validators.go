@@ -0,0 +1,504 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementations satisfy the expected interfaces.
+var (
+	_ validator.String = durationValidator{}
+	_ validator.String = cidrValidator{}
+	_ validator.String = ipAddressValidator{}
+	_ validator.String = regexValidator{}
+	_ validator.String = jsonObjectValidator{}
+	_ validator.Int64  = oddReplicasValidator{}
+
+	_ resource.ConfigValidator = clusterNetworkOverlapValidator{}
+	_ resource.ConfigValidator = clusterAutoscalingValidator{}
+	_ resource.ConfigValidator = clusterPoolNamesValidator{}
+	_ resource.ConfigValidator = clusterAutoscalingRangeValidator{}
+)
+
+// kubernetesVersionPattern matches Kubernetes release versions, e.g. `v1.28.3`.
+var kubernetesVersionPattern = regexp.MustCompile(`^v1\.(\d+)\.(\d+)$`)
+
+// resourceStatuses are the provisioning statuses the ECK API reports for
+// control planes and clusters.
+var resourceStatuses = []string{"Unknown", "Provisioning", "Provisioned", "Deprovisioning", "Error"}
+
+// minDiskSize and maxDiskSize bound the size, in GiB, of the volumes the
+// platform can provision for nodes.
+const (
+	minDiskSize = 10
+	maxDiskSize = 2000
+)
+
+// dnsLabelPattern matches RFC 1123 DNS labels, which the ECK API requires for
+// resource names.
+var dnsLabelPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// dnsLabelValidators returns the validators applied to resource names.
+func dnsLabelValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.LengthBetween(1, 63),
+		stringvalidator.RegexMatches(dnsLabelPattern, "Must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character"),
+	}
+}
+
+// headerNamePattern matches HTTP header field names, which are RFC 9110
+// tokens.
+var headerNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// headerNameValidators returns the validators applied to additional HTTP
+// header names, which must not replace the headers set by the provider.
+func headerNameValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.RegexMatches(headerNamePattern, "Must be a valid HTTP header name"),
+		stringvalidator.NoneOfCaseInsensitive("Authorization", "User-Agent"),
+	}
+}
+
+// durationValidator checks that a string is a positive Go duration, e.g. `30s`
+// or `10m`.
+type durationValidator struct{}
+
+// validDuration returns a validator which ensures the configured string can be
+// parsed by time.ParseDuration and is greater than zero.
+func validDuration() validator.String {
+	return durationValidator{}
+}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration such as `30s` or `10m`"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// cidrValidator checks that a string is an IPv4 or IPv6 CIDR-formatted
+// range, e.g. `10.0.0.0/16` or `fd00::/64`.
+type cidrValidator struct{}
+
+// validCIDR returns a validator which ensures the configured string can be
+// parsed by net.ParseCIDR.
+func validCIDR() validator.String {
+	return cidrValidator{}
+}
+
+func (v cidrValidator) Description(_ context.Context) string {
+	return "value must be an IPv4 or IPv6 CIDR-formatted range such as `10.0.0.0/16` or `fd00::/64`"
+}
+
+func (v cidrValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cidrValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, _, err := net.ParseCIDR(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid CIDR Range",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// ipAddressValidator checks that a string is an IPv4 or IPv6 address.
+type ipAddressValidator struct{}
+
+// validIPAddress returns a validator which ensures the configured string can
+// be parsed by net.ParseIP.
+func validIPAddress() validator.String {
+	return ipAddressValidator{}
+}
+
+func (v ipAddressValidator) Description(_ context.Context) string {
+	return "value must be an IPv4 or IPv6 address such as `1.1.1.1` or `2606:4700:4700::1111`"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if net.ParseIP(req.ConfigValue.ValueString()) == nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IP Address",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// regexValidator checks that a string is a valid regular expression.
+type regexValidator struct{}
+
+// validRegex returns a validator which ensures the configured string can be
+// compiled by regexp.Compile.
+func validRegex() validator.String {
+	return regexValidator{}
+}
+
+func (v regexValidator) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Regular Expression",
+			fmt.Sprintf("Attribute %s %s: %s", req.Path, v.Description(ctx), err),
+		)
+	}
+}
+
+// jsonObjectValidator checks that a string is a JSON object.
+type jsonObjectValidator struct{}
+
+// validJSONObject returns a validator which ensures the configured string can
+// be decoded as a JSON object.
+func validJSONObject() validator.String {
+	return jsonObjectValidator{}
+}
+
+func (v jsonObjectValidator) Description(_ context.Context) string {
+	return "value must be a JSON object"
+}
+
+func (v jsonObjectValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonObjectValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := decodeJSONObject([]byte(req.ConfigValue.ValueString())); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON Object",
+			fmt.Sprintf("Attribute %s %s: %s", req.Path, v.Description(ctx), err),
+		)
+	}
+}
+
+// oddReplicasValidator checks that a replica count is a positive odd number,
+// as required for etcd to maintain quorum.
+type oddReplicasValidator struct{}
+
+// oddReplicas returns a validator which ensures the configured integer is odd
+// and at least 1.
+func oddReplicas() validator.Int64 {
+	return oddReplicasValidator{}
+}
+
+func (v oddReplicasValidator) Description(_ context.Context) string {
+	return "value must be an odd number of at least 1"
+}
+
+func (v oddReplicasValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v oddReplicasValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	replicas := req.ConfigValue.ValueInt64()
+	if replicas < 1 || replicas%2 == 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Control Plane Replicas",
+			fmt.Sprintf("Attribute %s %s, got: %d", req.Path, v.Description(ctx), replicas),
+		)
+	}
+}
+
+// hasUnknownObjects reports whether a configuration value contains an unknown
+// object, or an unknown list or set of objects, e.g. workload pools built from
+// another resource.  Models decode these into pointers and slices, which
+// cannot hold unknown values, so they can only be validated once known.
+func hasUnknownObjects(value tftypes.Value) bool {
+	unknown := false
+
+	_ = tftypes.Walk(value, func(_ *tftypes.AttributePath, v tftypes.Value) (bool, error) {
+		if v.IsKnown() {
+			return true, nil
+		}
+
+		switch t := v.Type().(type) {
+		case tftypes.Object:
+			unknown = true
+		case tftypes.List:
+			_, unknown = t.ElementType.(tftypes.Object)
+		case tftypes.Set:
+			_, unknown = t.ElementType.(tftypes.Object)
+		}
+
+		return !unknown, nil
+	})
+
+	return unknown
+}
+
+// clusterNetworkOverlapValidator checks that the node, pod and service prefixes
+// of a cluster do not overlap, which would leave the cluster with broken
+// routing, and that they are all of the same IP family, as clusters are
+// single-stack.
+type clusterNetworkOverlapValidator struct{}
+
+func (v clusterNetworkOverlapValidator) Description(_ context.Context) string {
+	return "clusternetwork nodeprefix, podprefix and serviceprefix must not overlap and must be of the same IP family"
+}
+
+func (v clusterNetworkOverlapValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v clusterNetworkOverlapValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var object types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("clusternetwork"), &object)...)
+	if resp.Diagnostics.HasError() || object.IsNull() || object.IsUnknown() {
+		return
+	}
+
+	var network clusterNetworkModel
+	resp.Diagnostics.Append(object.As(ctx, &network, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prefixes := []struct {
+		name  string
+		value types.String
+	}{
+		{"nodeprefix", network.NodePrefix},
+		{"podprefix", network.PodPrefix},
+		{"serviceprefix", network.ServicePrefix},
+	}
+
+	for i, a := range prefixes {
+		_, aNet, err := net.ParseCIDR(a.value.ValueString())
+		if a.value.IsNull() || a.value.IsUnknown() || err != nil {
+			continue
+		}
+
+		for _, b := range prefixes[i+1:] {
+			_, bNet, err := net.ParseCIDR(b.value.ValueString())
+			if b.value.IsNull() || b.value.IsUnknown() || err != nil {
+				continue
+			}
+
+			if (aNet.IP.To4() == nil) != (bNet.IP.To4() == nil) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("clusternetwork").AtName(b.name),
+					"Mixed Cluster Network IP Families",
+					fmt.Sprintf("The %s %s and %s %s are of different IP families.  Dual-stack clusters are not supported, "+
+						"so node, pod and service prefixes must all be IPv4 or all be IPv6.", b.name, bNet, a.name, aNet),
+				)
+				continue
+			}
+
+			if aNet.Contains(bNet.IP) || bNet.Contains(aNet.IP) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("clusternetwork").AtName(b.name),
+					"Overlapping Cluster Network Prefixes",
+					fmt.Sprintf("The %s %s overlaps the %s %s.  Node, pod and service prefixes must be disjoint.",
+						b.name, bNet, a.name, aNet),
+				)
+			}
+		}
+	}
+}
+
+// clusterAutoscalingValidator checks that workload pools only configure
+// autoscaling when the cluster autoscaler is enabled, as otherwise nothing
+// scales them.
+type clusterAutoscalingValidator struct{}
+
+func (v clusterAutoscalingValidator) Description(_ context.Context) string {
+	return "workload pools may only set autoscaling when clusterfeatures.autoscaling is enabled"
+}
+
+func (v clusterAutoscalingValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v clusterAutoscalingValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var featuresObject types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("clusterfeatures"), &featuresObject)...)
+	if resp.Diagnostics.HasError() || featuresObject.IsUnknown() {
+		return
+	}
+
+	// The autoscaler defaults to disabled.
+	if !featuresObject.IsNull() {
+		var features clusterFeaturesModel
+		resp.Diagnostics.Append(featuresObject.As(ctx, &features, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() || features.Autoscaling.IsUnknown() || features.Autoscaling.ValueBool() {
+			return
+		}
+	}
+
+	for i, pool := range configWorkloadPools(ctx, req.Config, &resp.Diagnostics) {
+		autoscaling, ok := pool.Attributes()["autoscaling"]
+		if !ok || autoscaling.IsNull() || autoscaling.IsUnknown() {
+			continue
+		}
+
+		name, _ := pool.Attributes()["name"].(types.String)
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root("workloadnodepools").AtListIndex(i).AtName("autoscaling"),
+			"Cluster Autoscaler Not Enabled",
+			fmt.Sprintf("Workload pool %q sets autoscaling, but the cluster autoscaler is not enabled, so the pool would never be scaled.  "+
+				"Set clusterfeatures.autoscaling to true, or remove autoscaling from the pool.", name.ValueString()),
+		)
+	}
+}
+
+// clusterPoolNamesValidator checks that workload pool names are unique, as
+// each pool becomes a machine deployment named after it, so duplicates would
+// clobber one another.
+type clusterPoolNamesValidator struct{}
+
+func (v clusterPoolNamesValidator) Description(_ context.Context) string {
+	return "workload pool names must be unique within a cluster"
+}
+
+func (v clusterPoolNamesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v clusterPoolNamesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	poolNames := map[string]int{}
+
+	for i, pool := range configWorkloadPools(ctx, req.Config, &resp.Diagnostics) {
+		name, ok := pool.Attributes()["name"].(types.String)
+		if !ok || name.IsNull() || name.IsUnknown() {
+			continue
+		}
+
+		if first, ok := poolNames[name.ValueString()]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("workloadnodepools").AtListIndex(i).AtName("name"),
+				"Duplicate Workload Pool Name",
+				fmt.Sprintf("Workload pool name %q is already used by the pool at index %d.  Pool names must be unique within a cluster.",
+					name.ValueString(), first),
+			)
+
+			continue
+		}
+
+		poolNames[name.ValueString()] = i
+	}
+}
+
+// clusterAutoscalingRangeValidator checks that the autoscaling minimum of each
+// workload pool does not exceed its maximum.
+type clusterAutoscalingRangeValidator struct{}
+
+func (v clusterAutoscalingRangeValidator) Description(_ context.Context) string {
+	return "workload pool autoscaling minimums must not exceed their maximums"
+}
+
+func (v clusterAutoscalingRangeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v clusterAutoscalingRangeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	for i, pool := range configWorkloadPools(ctx, req.Config, &resp.Diagnostics) {
+		autoscaling, ok := pool.Attributes()["autoscaling"].(types.Object)
+		if !ok || autoscaling.IsNull() || autoscaling.IsUnknown() {
+			continue
+		}
+
+		minimum, _ := autoscaling.Attributes()["minimum"].(types.Int64)
+		maximum, _ := autoscaling.Attributes()["maximum"].(types.Int64)
+		if minimum.IsUnknown() || minimum.IsNull() || maximum.IsUnknown() || maximum.IsNull() {
+			continue
+		}
+
+		if minimum.ValueInt64() > maximum.ValueInt64() {
+			name, _ := pool.Attributes()["name"].(types.String)
+
+			resp.Diagnostics.AddAttributeError(
+				path.Root("workloadnodepools").AtListIndex(i).AtName("autoscaling").AtName("minimum"),
+				"Invalid Autoscaling Range",
+				fmt.Sprintf("The autoscaling minimum (%d) of workload pool %q must not exceed its maximum (%d).",
+					minimum.ValueInt64(), name.ValueString(), maximum.ValueInt64()),
+			)
+		}
+	}
+}
+
+// configWorkloadPools returns the workload pools of a cluster configuration
+// by index.  Pools are read as objects, attribute by attribute, as any of
+// their attributes may be unknown, and pools which are null or unknown are
+// returned as null objects so indexes match the configuration.
+func configWorkloadPools(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) []types.Object {
+	var pools types.List
+	diags.Append(config.GetAttribute(ctx, path.Root("workloadnodepools"), &pools)...)
+	if diags.HasError() || pools.IsNull() || pools.IsUnknown() {
+		return nil
+	}
+
+	objects := make([]types.Object, len(pools.Elements()))
+
+	for i, element := range pools.Elements() {
+		if pool, ok := element.(types.Object); ok && !pool.IsUnknown() {
+			objects[i] = pool
+		}
+	}
+
+	return objects
+}
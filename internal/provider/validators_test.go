@@ -0,0 +1,548 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testClusterSchema returns the eck_cluster schema and its object type.
+func testClusterSchema(t *testing.T) (resource.SchemaResponse, tftypes.Object) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var resp resource.SchemaResponse
+	(&clusterResource{}).Schema(ctx, resource.SchemaRequest{}, &resp)
+
+	objectType, ok := resp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatal("cluster schema is not an object")
+	}
+
+	return resp, objectType
+}
+
+// testClusterConfig returns an eck_cluster configuration with the given
+// attributes set and all others null.
+func testClusterConfig(t *testing.T, attributes map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	schemaResp, objectType := testClusterSchema(t)
+
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	for name, value := range attributes {
+		if _, ok := objectType.AttributeTypes[name]; !ok {
+			t.Fatalf("cluster schema has no attribute %q", name)
+		}
+
+		values[name] = value
+	}
+
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, values),
+	}
+}
+
+// testClusterAttributeType returns the type of an eck_cluster attribute.
+func testClusterAttributeType(t *testing.T, name string) tftypes.Type {
+	t.Helper()
+
+	_, objectType := testClusterSchema(t)
+
+	return objectType.AttributeTypes[name]
+}
+
+func TestHasUnknownObjects(t *testing.T) {
+	t.Parallel()
+
+	poolType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":   tftypes.String,
+		"labels": tftypes.Map{ElementType: tftypes.String},
+	}}
+	poolsType := tftypes.List{ElementType: poolType}
+	configType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":              tftypes.String,
+		"workloadnodepools": poolsType,
+	}}
+
+	config := func(name tftypes.Value, pools tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(configType, map[string]tftypes.Value{
+			"name":              name,
+			"workloadnodepools": pools,
+		})
+	}
+
+	pool := func(name tftypes.Value, labels tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(poolType, map[string]tftypes.Value{
+			"name":   name,
+			"labels": labels,
+		})
+	}
+
+	known := tftypes.NewValue(tftypes.String, "cpu")
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	labels := tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil)
+
+	tests := []struct {
+		name  string
+		value tftypes.Value
+		want  bool
+	}{
+		{
+			name:  "known",
+			value: config(known, tftypes.NewValue(poolsType, []tftypes.Value{pool(known, labels)})),
+			want:  false,
+		},
+		{
+			name:  "null list",
+			value: config(known, tftypes.NewValue(poolsType, nil)),
+			want:  false,
+		},
+		{
+			name:  "unknown string",
+			value: config(unknown, tftypes.NewValue(poolsType, []tftypes.Value{pool(unknown, labels)})),
+			want:  false,
+		},
+		{
+			name:  "unknown map",
+			value: config(known, tftypes.NewValue(poolsType, []tftypes.Value{pool(known, tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, tftypes.UnknownValue))})),
+			want:  false,
+		},
+		{
+			name:  "unknown list of objects",
+			value: config(known, tftypes.NewValue(poolsType, tftypes.UnknownValue)),
+			want:  true,
+		},
+		{
+			name:  "unknown object",
+			value: config(known, tftypes.NewValue(poolsType, []tftypes.Value{tftypes.NewValue(poolType, tftypes.UnknownValue)})),
+			want:  true,
+		},
+		{
+			name:  "unknown configuration",
+			value: tftypes.NewValue(configType, tftypes.UnknownValue),
+			want:  true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := hasUnknownObjects(test.value); got != test.want {
+				t.Errorf("hasUnknownObjects() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestClusterNetworkOverlapValidator(t *testing.T) {
+	t.Parallel()
+
+	networkType := testClusterAttributeType(t, "clusternetwork").(tftypes.Object)
+
+	prefix := func(value string) tftypes.Value {
+		if value == "" {
+			return tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+		}
+
+		return tftypes.NewValue(tftypes.String, value)
+	}
+
+	network := func(node, pod, service string) tftypes.Value {
+		return tftypes.NewValue(networkType, map[string]tftypes.Value{
+			"dnsnameservers": tftypes.NewValue(networkType.AttributeTypes["dnsnameservers"], nil),
+			"nodeprefix":     prefix(node),
+			"podprefix":      prefix(pod),
+			"serviceprefix":  prefix(service),
+		})
+	}
+
+	tests := []struct {
+		name    string
+		network tftypes.Value
+		want    []string
+	}{
+		{
+			name:    "disjoint",
+			network: network("192.168.0.0/24", "10.0.0.0/16", "172.16.0.0/12"),
+		},
+		{
+			name:    "disjoint IPv6",
+			network: network("2001:db8::/64", "2001:db8:1::/56", "2001:db8:2::/108"),
+		},
+		{
+			name:    "overlapping",
+			network: network("10.0.0.0/16", "10.0.128.0/17", "172.16.0.0/12"),
+			want:    []string{"Overlapping Cluster Network Prefixes"},
+		},
+		{
+			name:    "all overlapping",
+			network: network("10.0.0.0/8", "10.1.0.0/16", "10.2.0.0/16"),
+			want:    []string{"Overlapping Cluster Network Prefixes", "Overlapping Cluster Network Prefixes"},
+		},
+		{
+			name:    "mixed families",
+			network: network("192.168.0.0/24", "2001:db8::/56", "172.16.0.0/12"),
+			want:    []string{"Mixed Cluster Network IP Families", "Mixed Cluster Network IP Families"},
+		},
+		{
+			name:    "unknown prefix",
+			network: network("10.0.0.0/16", "", "10.0.128.0/17"),
+			want:    []string{"Overlapping Cluster Network Prefixes"},
+		},
+		{
+			name:    "unknown prefixes",
+			network: network("10.0.0.0/16", "", ""),
+		},
+		{
+			name:    "invalid prefix",
+			network: network("10.0.0.0/16", "not-a-prefix", "10.0.0.0/16"),
+			want:    []string{"Overlapping Cluster Network Prefixes"},
+		},
+		{
+			name:    "unknown network",
+			network: tftypes.NewValue(networkType, tftypes.UnknownValue),
+		},
+		{
+			name:    "null network",
+			network: tftypes.NewValue(networkType, nil),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := resource.ValidateConfigRequest{
+				Config: testClusterConfig(t, map[string]tftypes.Value{"clusternetwork": test.network}),
+			}
+
+			var resp resource.ValidateConfigResponse
+			clusterNetworkOverlapValidator{}.ValidateResource(context.Background(), req, &resp)
+
+			var got []string
+			for _, d := range resp.Diagnostics {
+				got = append(got, d.Summary())
+			}
+
+			if len(got) != len(test.want) {
+				t.Fatalf("diagnostics = %v, want %v", got, test.want)
+			}
+
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Errorf("diagnostics = %v, want %v", got, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestClusterAutoscalingValidator(t *testing.T) {
+	t.Parallel()
+
+	featuresType := testClusterAttributeType(t, "clusterfeatures").(tftypes.Object)
+	poolsType := testClusterAttributeType(t, "workloadnodepools").(tftypes.List)
+	poolType := poolsType.ElementType.(tftypes.Object)
+	autoscalingType := poolType.AttributeTypes["autoscaling"].(tftypes.Object)
+
+	features := func(autoscaling tftypes.Value) tftypes.Value {
+		values := map[string]tftypes.Value{}
+		for name, attributeType := range featuresType.AttributeTypes {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+
+		values["autoscaling"] = autoscaling
+
+		return tftypes.NewValue(featuresType, values)
+	}
+
+	pool := func(autoscaling tftypes.Value) tftypes.Value {
+		values := map[string]tftypes.Value{}
+		for name, attributeType := range poolType.AttributeTypes {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+
+		values["name"] = tftypes.NewValue(tftypes.String, "cpu")
+		values["autoscaling"] = autoscaling
+
+		return tftypes.NewValue(poolType, values)
+	}
+
+	autoscaled := tftypes.NewValue(autoscalingType, map[string]tftypes.Value{
+		"minimum": tftypes.NewValue(tftypes.Number, 1),
+		"maximum": tftypes.NewValue(tftypes.Number, 3),
+	})
+
+	tests := []struct {
+		name     string
+		features tftypes.Value
+		pools    tftypes.Value
+		want     int
+	}{
+		{
+			name:     "autoscaler enabled",
+			features: features(tftypes.NewValue(tftypes.Bool, true)),
+			pools:    tftypes.NewValue(poolsType, []tftypes.Value{pool(autoscaled)}),
+		},
+		{
+			name:     "autoscaler disabled",
+			features: features(tftypes.NewValue(tftypes.Bool, false)),
+			pools:    tftypes.NewValue(poolsType, []tftypes.Value{pool(autoscaled)}),
+			want:     1,
+		},
+		{
+			name:     "features not configured",
+			features: tftypes.NewValue(featuresType, nil),
+			pools:    tftypes.NewValue(poolsType, []tftypes.Value{pool(autoscaled), pool(tftypes.NewValue(autoscalingType, nil))}),
+			want:     1,
+		},
+		{
+			name:     "no autoscaled pools",
+			features: features(tftypes.NewValue(tftypes.Bool, false)),
+			pools:    tftypes.NewValue(poolsType, []tftypes.Value{pool(tftypes.NewValue(autoscalingType, nil))}),
+		},
+		{
+			name:     "unknown features",
+			features: tftypes.NewValue(featuresType, tftypes.UnknownValue),
+			pools:    tftypes.NewValue(poolsType, []tftypes.Value{pool(autoscaled)}),
+		},
+		{
+			name:     "unknown autoscaler",
+			features: features(tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue)),
+			pools:    tftypes.NewValue(poolsType, []tftypes.Value{pool(autoscaled)}),
+		},
+		{
+			name:     "unknown pools",
+			features: features(tftypes.NewValue(tftypes.Bool, false)),
+			pools:    tftypes.NewValue(poolsType, tftypes.UnknownValue),
+		},
+		{
+			name:     "unknown pool",
+			features: features(tftypes.NewValue(tftypes.Bool, false)),
+			pools:    tftypes.NewValue(poolsType, []tftypes.Value{tftypes.NewValue(poolType, tftypes.UnknownValue)}),
+		},
+		{
+			name:     "unknown pool autoscaling",
+			features: features(tftypes.NewValue(tftypes.Bool, false)),
+			pools:    tftypes.NewValue(poolsType, []tftypes.Value{pool(tftypes.NewValue(autoscalingType, tftypes.UnknownValue))}),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := resource.ValidateConfigRequest{
+				Config: testClusterConfig(t, map[string]tftypes.Value{
+					"clusterfeatures":   test.features,
+					"workloadnodepools": test.pools,
+				}),
+			}
+
+			var resp resource.ValidateConfigResponse
+			clusterAutoscalingValidator{}.ValidateResource(context.Background(), req, &resp)
+
+			if got := resp.Diagnostics.ErrorsCount(); got != test.want || len(resp.Diagnostics) != test.want {
+				t.Errorf("diagnostics = %v, want %d errors", resp.Diagnostics, test.want)
+			}
+		})
+	}
+}
+
+// testPoolValues returns constructors for eck_cluster workload pool values
+// with the given name and autoscaling range, and all other attributes null.
+func testPoolValues(t *testing.T) (func(name tftypes.Value, autoscaling tftypes.Value) tftypes.Value, func(minimum, maximum tftypes.Value) tftypes.Value, tftypes.List) {
+	t.Helper()
+
+	poolsType := testClusterAttributeType(t, "workloadnodepools").(tftypes.List)
+	poolType := poolsType.ElementType.(tftypes.Object)
+	autoscalingType := poolType.AttributeTypes["autoscaling"].(tftypes.Object)
+
+	pool := func(name tftypes.Value, autoscaling tftypes.Value) tftypes.Value {
+		values := map[string]tftypes.Value{}
+		for attribute, attributeType := range poolType.AttributeTypes {
+			values[attribute] = tftypes.NewValue(attributeType, nil)
+		}
+
+		values["name"] = name
+		values["autoscaling"] = autoscaling
+
+		return tftypes.NewValue(poolType, values)
+	}
+
+	autoscaling := func(minimum, maximum tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(autoscalingType, map[string]tftypes.Value{
+			"minimum": minimum,
+			"maximum": maximum,
+		})
+	}
+
+	return pool, autoscaling, poolsType
+}
+
+func TestClusterPoolNamesValidator(t *testing.T) {
+	t.Parallel()
+
+	pool, _, poolsType := testPoolValues(t)
+	poolType := poolsType.ElementType
+
+	named := func(name string) tftypes.Value {
+		value := tftypes.NewValue(tftypes.String, name)
+		if name == "" {
+			value = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+		}
+
+		return pool(value, tftypes.NewValue(poolType.(tftypes.Object).AttributeTypes["autoscaling"], nil))
+	}
+
+	tests := []struct {
+		name  string
+		pools tftypes.Value
+		want  []path.Path
+	}{
+		{
+			name:  "unique",
+			pools: tftypes.NewValue(poolsType, []tftypes.Value{named("cpu"), named("gpu")}),
+		},
+		{
+			name:  "duplicate",
+			pools: tftypes.NewValue(poolsType, []tftypes.Value{named("cpu"), named("gpu"), named("cpu")}),
+			want:  []path.Path{path.Root("workloadnodepools").AtListIndex(2).AtName("name")},
+		},
+		{
+			name:  "duplicates",
+			pools: tftypes.NewValue(poolsType, []tftypes.Value{named("cpu"), named("cpu"), named("cpu")}),
+			want: []path.Path{
+				path.Root("workloadnodepools").AtListIndex(1).AtName("name"),
+				path.Root("workloadnodepools").AtListIndex(2).AtName("name"),
+			},
+		},
+		{
+			name:  "unknown name",
+			pools: tftypes.NewValue(poolsType, []tftypes.Value{named("cpu"), named(""), named("")}),
+		},
+		{
+			name:  "unknown pool",
+			pools: tftypes.NewValue(poolsType, []tftypes.Value{named("cpu"), tftypes.NewValue(poolType, tftypes.UnknownValue), named("cpu")}),
+			want:  []path.Path{path.Root("workloadnodepools").AtListIndex(2).AtName("name")},
+		},
+		{
+			name:  "unknown pools",
+			pools: tftypes.NewValue(poolsType, tftypes.UnknownValue),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := resource.ValidateConfigRequest{
+				Config: testClusterConfig(t, map[string]tftypes.Value{"workloadnodepools": test.pools}),
+			}
+
+			var resp resource.ValidateConfigResponse
+			clusterPoolNamesValidator{}.ValidateResource(context.Background(), req, &resp)
+
+			testDiagnosticPaths(t, resp.Diagnostics, test.want)
+		})
+	}
+}
+
+func TestClusterAutoscalingRangeValidator(t *testing.T) {
+	t.Parallel()
+
+	pool, autoscaling, poolsType := testPoolValues(t)
+
+	number := func(n int64) tftypes.Value {
+		return tftypes.NewValue(tftypes.Number, n)
+	}
+
+	unknown := tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)
+	name := tftypes.NewValue(tftypes.String, "cpu")
+
+	tests := []struct {
+		name  string
+		pools tftypes.Value
+		want  []path.Path
+	}{
+		{
+			name:  "valid range",
+			pools: tftypes.NewValue(poolsType, []tftypes.Value{pool(name, autoscaling(number(1), number(3)))}),
+		},
+		{
+			name:  "fixed size",
+			pools: tftypes.NewValue(poolsType, []tftypes.Value{pool(name, autoscaling(number(3), number(3)))}),
+		},
+		{
+			name:  "minimum exceeds maximum",
+			pools: tftypes.NewValue(poolsType, []tftypes.Value{pool(name, autoscaling(number(1), number(3))), pool(name, autoscaling(number(4), number(3)))}),
+			want:  []path.Path{path.Root("workloadnodepools").AtListIndex(1).AtName("autoscaling").AtName("minimum")},
+		},
+		{
+			name:  "unknown minimum",
+			pools: tftypes.NewValue(poolsType, []tftypes.Value{pool(name, autoscaling(unknown, number(3)))}),
+		},
+		{
+			name:  "unknown autoscaling",
+			pools: tftypes.NewValue(poolsType, []tftypes.Value{pool(name, tftypes.NewValue(poolsType.ElementType.(tftypes.Object).AttributeTypes["autoscaling"], tftypes.UnknownValue))}),
+		},
+		{
+			name:  "unknown pools",
+			pools: tftypes.NewValue(poolsType, tftypes.UnknownValue),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := resource.ValidateConfigRequest{
+				Config: testClusterConfig(t, map[string]tftypes.Value{"workloadnodepools": test.pools}),
+			}
+
+			var resp resource.ValidateConfigResponse
+			clusterAutoscalingRangeValidator{}.ValidateResource(context.Background(), req, &resp)
+
+			testDiagnosticPaths(t, resp.Diagnostics, test.want)
+		})
+	}
+}
+
+// testDiagnosticPaths checks that diagnostics are errors for the given paths.
+func testDiagnosticPaths(t *testing.T, diags diag.Diagnostics, want []path.Path) {
+	t.Helper()
+
+	if len(diags) != len(want) || diags.ErrorsCount() != len(want) {
+		t.Fatalf("diagnostics = %v, want errors for %v", diags, want)
+	}
+
+	for i := range want {
+		withPath, ok := diags[i].(diag.DiagnosticWithPath)
+		if !ok || !withPath.Path().Equal(want[i]) {
+			t.Errorf("diagnostics = %v, want errors for %v", diags, want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &versionAtLeastFunction{}
+)
+
+// minimumVersionPattern matches a minimum Kubernetes version, which may omit
+// the patch release, e.g. `v1.28` or `v1.28.3`.
+var minimumVersionPattern = regexp.MustCompile(`^v1\.(\d+)(?:\.(\d+))?$`)
+
+// NewVersionAtLeastFunction is a helper function to simplify the provider implementation.
+func NewVersionAtLeastFunction() function.Function {
+	return &versionAtLeastFunction{}
+}
+
+// versionAtLeastFunction is the function implementation.
+type versionAtLeastFunction struct{}
+
+// Metadata returns the function name.
+func (f *versionAtLeastFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "version_at_least"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *versionAtLeastFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Check a Kubernetes version is at least a minimum version.",
+		Description: "Returns true when a Kubernetes version, such as `v1.28.3`, is the same as or newer than a minimum version.  The minimum version may omit the patch release, so `v1.28` matches any v1.28 release.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "version",
+				Description: "Kubernetes version to check, e.g. `v1.28.3`.",
+			},
+			function.StringParameter{
+				Name:        "constraint",
+				Description: "Minimum Kubernetes version, e.g. `v1.28` or `v1.28.3`.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+// Run compares the versions.
+func (f *versionAtLeastFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var version, constraint string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &version, &constraint))
+	if resp.Error != nil {
+		return
+	}
+
+	v, err := parseKubernetesVersion(version)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid version: must be a Kubernetes version such as v1.28.3, got: "+version)
+		return
+	}
+
+	matches := minimumVersionPattern.FindStringSubmatch(constraint)
+	if matches == nil {
+		resp.Error = function.NewArgumentFuncError(1, "Invalid constraint: must be a Kubernetes version such as v1.28 or v1.28.3, got: "+constraint)
+		return
+	}
+
+	// An omitted patch release is treated as 0, which every release matches.
+	minor, _ := strconv.Atoi(matches[1])
+	patch, _ := strconv.Atoi(matches[2])
+
+	result := v[0] > minor || (v[0] == minor && v[1] >= patch)
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
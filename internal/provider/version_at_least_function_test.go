@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestVersionAtLeastFunction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		version    string
+		constraint string
+		want       bool
+		wantErr    *function.FuncError
+	}{
+		{name: "same version", version: "v1.28.3", constraint: "v1.28.3", want: true},
+		{name: "newer patch", version: "v1.28.4", constraint: "v1.28.3", want: true},
+		{name: "older patch", version: "v1.28.2", constraint: "v1.28.3", want: false},
+		{name: "newer minor", version: "v1.29.0", constraint: "v1.28.3", want: true},
+		{name: "older minor", version: "v1.27.9", constraint: "v1.28.0", want: false},
+		{name: "minor only", version: "v1.28.0", constraint: "v1.28", want: true},
+		{name: "minor only older", version: "v1.27.12", constraint: "v1.28", want: false},
+		{name: "numeric comparison", version: "v1.100.0", constraint: "v1.99", want: true},
+		{
+			name:       "version without patch",
+			version:    "v1.28",
+			constraint: "v1.28",
+			wantErr:    testFuncError(0, "Invalid version: must be a Kubernetes version such as v1.28.3, got: v1.28"),
+		},
+		{
+			name:       "version without prefix",
+			version:    "1.28.3",
+			constraint: "v1.28",
+			wantErr:    testFuncError(0, "Invalid version: must be a Kubernetes version such as v1.28.3, got: 1.28.3"),
+		},
+		{
+			name:       "invalid constraint",
+			version:    "v1.28.3",
+			constraint: ">= v1.28",
+			wantErr:    testFuncError(1, "Invalid constraint: must be a Kubernetes version such as v1.28 or v1.28.3, got: >= v1.28"),
+		},
+		{
+			name:       "major version constraint",
+			version:    "v1.28.3",
+			constraint: "v2.0",
+			wantErr:    testFuncError(1, "Invalid constraint: must be a Kubernetes version such as v1.28 or v1.28.3, got: v2.0"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := testRunFunction(t, NewVersionAtLeastFunction(), types.StringValue(test.version), types.StringValue(test.constraint))
+			if !err.Equal(test.wantErr) {
+				t.Fatalf("error = %v, want %v", err, test.wantErr)
+			}
+
+			if test.wantErr == nil && !got.Equal(types.BoolValue(test.want)) {
+				t.Errorf("result = %s, want %t", got, test.want)
+			}
+		})
+	}
+}